@@ -0,0 +1,32 @@
+// Command xtemplate renders a template with a restricted set of functions.
+//
+// Usage:
+//
+//	xtemplate [flags] [template]
+//
+// The template is read from the given file, or from stdin if no file (or "-") is given.
+//
+// Flags:
+//
+//	-data file        read data from a JSON file ("-" for stdin), can be repeated
+//	-set key=value    set a data value, nested keys are separated by dots, can be repeated
+//	-allow list       allow a comma separated list of functions, e.g. "strings.*,os.Getenv", can be repeated
+//	-policy file      allow the functions listed in a policy file, can be repeated
+//	-o file           write the output to file instead of stdout
+//
+// Exit status:
+//
+//	0  the template was rendered (this includes templates that used return)
+//	1  the template called error
+//	2  the command line was invalid
+//	3  the template called a function that is not allowed
+//	4  the template could not be read, parsed or executed
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Exit codes returned by run.
+const (
+	exitOK             = 0
+	exitCustomError    = 1
+	exitUsage          = 2
+	exitFuncNotAllowed = 3
+	exitFailure        = 4
+)
+
+const stdinName = "-"
+
+// errStdinUsedTwice is returned when more than one input should be read from stdin.
+var errStdinUsedTwice = errors.New("stdin can only be used once")
+
+// errInvalidSet is returned when a -set value is not in the key=value form.
+var errInvalidSet = errors.New("expected key=value")
+
+// errDataNotAnObject is returned when multiple data sources are combined and one of them is not a JSON object.
+var errDataNotAnObject = errors.New("data must be a JSON object when combined with other data")
+
+// stringList is a flag.Value that collects repeated flags.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+type config struct {
+	templateFile string
+	output       string
+	dataFiles    stringList
+	sets         stringList
+	allows       stringList
+	policies     stringList
+}
+
+func parseFlags(args []string, stderr io.Writer) (*config, error) {
+	var cfg config
+	fs := flag.NewFlagSet("xtemplate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&cfg.dataFiles, "data", "read data from a JSON `file` (\"-\" for stdin), can be repeated")
+	fs.Var(&cfg.sets, "set", "set a data value in the form `key=value`, can be repeated")
+	fs.Var(&cfg.allows, "allow", "allow a comma separated `list` of functions, e.g. \"strings.*,os.Getenv\"")
+	fs.Var(&cfg.policies, "policy", "allow the functions listed in a policy `file`, can be repeated")
+	fs.StringVar(&cfg.output, "o", "", "write the output to `file` instead of stdout")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "Usage: xtemplate [flags] [template]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	switch fs.NArg() {
+	case 0:
+		cfg.templateFile = stdinName
+	case 1:
+		cfg.templateFile = fs.Arg(0)
+	default:
+		fs.Usage()
+		//nolint:err113 // allow dynamic error
+		return nil, fmt.Errorf("expected at most one template, got %d", fs.NArg())
+	}
+	if cfg.templateFile == stdinName && slices.Contains(cfg.dataFiles, stdinName) {
+		return nil, errStdinUsedTwice
+	}
+	return &cfg, nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cfg, err := parseFlags(args, stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+		return exitUsage
+	}
+
+	in := &stdinReader{r: stdin}
+
+	allowed, err := loadAllowed(cfg)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+		return exitUsage
+	}
+
+	data, err := loadData(cfg, in)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+		return exitUsage
+	}
+
+	text, err := in.readFile(cfg.templateFile)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+		return exitFailure
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, cfg.templateFile, string(text), data, allowed); err != nil {
+		_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+		return exitCode(err)
+	}
+
+	if cfg.output == "" {
+		if _, err := stdout.Write(buf.Bytes()); err != nil {
+			_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+			return exitFailure
+		}
+		return exitOK
+	}
+	//nolint:gosec,mnd // the output file is chosen by the user
+	if err := os.WriteFile(cfg.output, buf.Bytes(), 0o644); err != nil {
+		_, _ = fmt.Fprintln(stderr, "xtemplate:", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+func render(wr io.Writer, name, text string, data any, allowed funcs.Funcs) error {
+	tmpl := template.New(name)
+	tmpl = tmpl.Funcs(xtemplate.FuncMap(tmpl, allowed))
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return xtemplate.Execute(tmpl, wr, data)
+}
+
+func exitCode(err error) int {
+	var customErr xtemplate.CustomError
+	if errors.As(err, &customErr) {
+		return exitCustomError
+	}
+	var notAllowedErr *xtemplate.FuncNotAllowedError
+	if errors.As(err, &notAllowedErr) {
+		return exitFuncNotAllowed
+	}
+	return exitFailure
+}
+
+func loadAllowed(cfg *config) (funcs.Funcs, error) {
+	var allowed funcs.Funcs
+	for _, list := range cfg.allows {
+		f, err := funcs.Parse(list)
+		if err != nil {
+			return nil, err
+		}
+		allowed = append(allowed, f...)
+	}
+	for _, name := range cfg.policies {
+		f, err := loadPolicy(name)
+		if err != nil {
+			return nil, err
+		}
+		allowed = append(allowed, f...)
+	}
+	return allowed, nil
+}
+
+func loadPolicy(name string) (funcs.Funcs, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open policy: %w", err)
+	}
+	defer f.Close()
+	allowed, err := funcs.ParsePolicy(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return allowed, nil
+}
+
+func loadData(cfg *config, in *stdinReader) (any, error) {
+	var data any
+	for _, name := range cfg.dataFiles {
+		buf, err := in.readFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if err := json.Unmarshal(buf, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		data, err = mergeData(data, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	for _, s := range cfg.sets {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid -set %q: %w", s, errInvalidSet)
+		}
+		var v any = value
+		parts := strings.Split(key, ".")
+		for i := len(parts) - 1; i >= 0; i-- {
+			v = map[string]any{parts[i]: v}
+		}
+		var err error
+		data, err = mergeData(data, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -set %q: %w", s, err)
+		}
+	}
+	return data, nil
+}
+
+// mergeData merges src into dst. Objects are merged recursively, all other values in src replace the
+// values in dst.
+func mergeData(dst, src any) (any, error) {
+	if dst == nil {
+		return src, nil
+	}
+	dstMap, ok := dst.(map[string]any)
+	if !ok {
+		return nil, errDataNotAnObject
+	}
+	srcMap, ok := src.(map[string]any)
+	if !ok {
+		return nil, errDataNotAnObject
+	}
+	for k, v := range srcMap {
+		existing, ok := dstMap[k].(map[string]any)
+		if sub, isMap := v.(map[string]any); ok && isMap {
+			merged, err := mergeData(existing, sub)
+			if err != nil {
+				return nil, err
+			}
+			dstMap[k] = merged
+			continue
+		}
+		dstMap[k] = v
+	}
+	return dstMap, nil
+}
+
+// stdinReader makes sure stdin is only consumed once.
+type stdinReader struct {
+	r    io.Reader
+	used bool
+}
+
+func (s *stdinReader) readFile(name string) ([]byte, error) {
+	if name != stdinName {
+		buf, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return buf, nil
+	}
+	if s.used {
+		return nil, errStdinUsedTwice
+	}
+	s.used = true
+	buf, err := io.ReadAll(s.r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	dataFile := writeFile("data.json", `{"user":{"name":"Joe","age":42}}`)
+	policyFile := writeFile("policy.txt", "# strings only\nstrings.*\n")
+	tmplFile := writeFile("hello.tmpl", `Hello {{ strings.ToUpper .user.name }}`)
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStdout string
+		wantCode   int
+	}{
+		{
+			name:       "template from stdin",
+			args:       []string{"-allow", "strings.*", "-set", "name=World"},
+			stdin:      `Hello {{ strings.ToLower .name }}`,
+			wantStdout: "Hello world",
+			wantCode:   exitOK,
+		},
+		{
+			name:       "template from file with data and policy",
+			args:       []string{"-data", dataFile, "-policy", policyFile, tmplFile},
+			wantStdout: "Hello JOE",
+			wantCode:   exitOK,
+		},
+		{
+			name:       "data from stdin merged with set",
+			args:       []string{"-data", "-", "-set", "user.name=Jane", "-allow", "strings.ToUpper", tmplFile},
+			stdin:      `{"user":{"name":"Joe"}}`,
+			wantStdout: "Hello JANE",
+			wantCode:   exitOK,
+		},
+		{
+			name:       "return",
+			stdin:      `Hello {{ return "World" }} and Universe`,
+			wantStdout: "Hello World",
+			wantCode:   exitOK,
+		},
+		{
+			name:     "error",
+			stdin:    `{{ error "oh no" }}`,
+			wantCode: exitCustomError,
+		},
+		{
+			name:     "function not allowed",
+			args:     []string{"-allow", "strings.ToUpper"},
+			stdin:    `{{ strings.ToLower "A" }}`,
+			wantCode: exitFuncNotAllowed,
+		},
+		{
+			name:     "parse error",
+			stdin:    `{{ `,
+			wantCode: exitFailure,
+		},
+		{
+			name:     "unknown function in allow list",
+			args:     []string{"-allow", "strings.Unknown"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "stdin used twice",
+			args:     []string{"-data", "-"},
+			wantCode: exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() = %d, want %d (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("run() stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestRunOutputFile(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.txt")
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-o", out, "-set", "name=World"}, strings.NewReader(`Hello {{ .name }}`), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	buf, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "Hello World" {
+		t.Errorf("output = %q, want %q", string(buf), "Hello World")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+}
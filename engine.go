@@ -0,0 +1,66 @@
+package xtemplate
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// Engine holds a set of allowed functions and binds them to templates.
+//
+// A FuncMap is bound to the template it was created for, so a template set created with t.Clone()
+// still executes the templates of the original set when calling tmpl.Exec.
+// An Engine binds its namespaces to the template that is actually executed, which makes it safe to use
+// with cloned template sets, e.g. for per-request overrides.
+type Engine struct {
+	allowedFunctions []AllowedFunctions
+}
+
+// New creates an Engine that allows the functions specified in allowedFunctions.
+func New(allowedFunctions ...AllowedFunctions) *Engine {
+	return &Engine{
+		allowedFunctions: allowedFunctions,
+	}
+}
+
+// FuncMap returns a template.FuncMap bound to t containing only the functions allowed by the Engine.
+func (e *Engine) FuncMap(t *template.Template) template.FuncMap {
+	return FuncMap(t, e.allowedFunctions...)
+}
+
+// Bind adds the functions allowed by the Engine to t and binds them to t.
+// It returns t to allow chaining.
+func (e *Engine) Bind(t *template.Template) *template.Template {
+	return t.Funcs(e.FuncMap(t))
+}
+
+// Clone returns a duplicate of t, including all associated templates, with the functions of the Engine
+// bound to the duplicate.
+func (e *Engine) Clone(t *template.Template) (*template.Template, error) {
+	c, err := t.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template: %w", err)
+	}
+	return e.Bind(c), nil
+}
+
+// Execute executes t with the provided data and writes the result to the given writer.
+// The functions of the Engine are bound to a clone of t, t itself is not modified, so a template can be
+// executed concurrently by several Engines.
+func (e *Engine) Execute(t *template.Template, wr io.Writer, data any) error {
+	c, err := e.Clone(t)
+	if err != nil {
+		return err
+	}
+	return Execute(c, wr, data)
+}
+
+// ExecuteTemplate executes the named template within t with the provided data and writes the result to
+// the given writer. Like Execute it binds the functions of the Engine to a clone of t.
+func (e *Engine) ExecuteTemplate(t *template.Template, wr io.Writer, name string, data any) error {
+	c, err := e.Clone(t)
+	if err != nil {
+		return err
+	}
+	return ExecuteTemplate(c, wr, name, data)
+}
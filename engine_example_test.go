@@ -0,0 +1,28 @@
+package xtemplate_test
+
+import (
+	"os"
+	"text/template"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleEngine_Clone() {
+	engine := xtemplate.New(funcs.Safe)
+
+	base := engine.Bind(template.New("base"))
+	base = template.Must(base.Parse(`{{ define "name" }}World{{ end }}Hello {{ tmpl.Exec "name" }}`))
+
+	// override the "name" template for a single request
+	override, err := engine.Clone(base)
+	if err != nil {
+		panic(err)
+	}
+	override = template.Must(override.Parse(`{{ define "name" }}Universe{{ end }}`))
+
+	err = engine.Execute(override, os.Stdout, nil) // Output: Hello Universe
+	if err != nil {
+		panic(err)
+	}
+}
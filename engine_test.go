@@ -0,0 +1,128 @@
+package xtemplate_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestEngine_Clone(t *testing.T) {
+	t.Parallel()
+
+	engine := xtemplate.New(funcs.Safe)
+
+	base := engine.Bind(template.New("base"))
+	base, err := base.Parse(`{{ define "greeting" }}Hello{{ end }}{{ tmpl.Exec "greeting" }} {{ tmpl.Exec "name" }}`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	base, err = base.New("name").Parse(`World`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	base = base.Lookup("base")
+
+	tests := []struct {
+		name  string
+		clone func() (*template.Template, error)
+	}{
+		{
+			name: "engine clone",
+			clone: func() (*template.Template, error) {
+				return engine.Clone(base)
+			},
+		},
+		{
+			name: "template clone",
+			clone: func() (*template.Template, error) {
+				return base.Clone()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clone, err := tt.clone()
+			if err != nil {
+				t.Fatalf("Clone() error = %v", err)
+			}
+			_, err = clone.New("name").Parse(`Universe`)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := engine.Execute(clone, &buf, nil); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if want := "Hello Universe"; buf.String() != want {
+				t.Errorf("Execute() got = %q, want %q", buf.String(), want)
+			}
+
+			buf.Reset()
+			if err := engine.Execute(base, &buf, nil); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if want := "Hello World"; buf.String() != want {
+				t.Errorf("Execute() got = %q, want %q", buf.String(), want)
+			}
+		})
+	}
+}
+
+// engineBarrier pauses an execution until another execution has finished.
+type engineBarrier struct {
+	paused chan struct{}
+	resume chan struct{}
+}
+
+func (b *engineBarrier) Wait() string {
+	close(b.paused)
+	<-b.resume
+	return ""
+}
+
+func TestEngine_ConcurrentEngines(t *testing.T) {
+	t.Parallel()
+
+	upper := xtemplate.New(funcs.StringsToUpper)
+	lower := xtemplate.New(funcs.StringsToLower)
+	tmpl, err := upper.Bind(template.New("upper")).
+		Parse(`{{ strings.ToUpper "a" }}{{ .Wait }}{{ strings.ToUpper "b" }}`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, err = tmpl.New("lower").Parse(`{{ strings.ToLower "C" }}`); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// the first engine pauses in the middle of the execution while the second engine executes the template
+	barrier := &engineBarrier{paused: make(chan struct{}), resume: make(chan struct{})}
+	var got [2]bytes.Buffer
+	var errs [2]error
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		errs[0] = upper.Execute(tmpl, &got[0], barrier)
+	})
+	<-barrier.paused
+	wg.Go(func() {
+		defer close(barrier.resume)
+		errs[1] = lower.ExecuteTemplate(tmpl, &got[1], "lower", nil)
+	})
+	wg.Wait()
+
+	for i, want := range []string{"AB", "c"} {
+		if errs[i] != nil {
+			t.Fatalf("Execute() error = %v", errs[i])
+		}
+		if got[i].String() != want {
+			t.Errorf("Execute() got = %q, want %q", got[i].String(), want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package xtemplate
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Case provides functions to convert identifiers between naming styles.
+//
+// Words are separated by any character that is neither a letter nor a digit, by a change from a lower case letter
+// or digit to an upper case letter, and at the end of an acronym, so HTTPServer consists of the words HTTP and Server.
+// Digits belong to the word they follow.
+type Case rootContext
+
+// splitWords splits s into words, see Case for the rules.
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		if unicode.IsUpper(r) {
+			// combining marks belong to the letter before them
+			j := i - 1
+			for j > start && unicode.IsMark(runes[j]) {
+				j--
+			}
+			prev := runes[j]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// titleWord maps the first letter of word to title case and the rest to lower case.
+func titleWord(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToTitle(r)) + strings.ToLower(word[size:])
+}
+
+func joinWords(s, sep string, fn func(i int, word string) string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = fn(i, word)
+	}
+	return strings.Join(words, sep)
+}
+
+// Split returns the words of s.
+//
+// Example:
+//
+//	{{ case.Split "parseHTTPResponse2XXStatus" }} // Output: [parse HTTP Response2 XX Status]
+func (ctx Case) Split(s string) ([]string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseSplit]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.CaseSplit}
+	}
+	if fn, ok := override[func(string) ([]string, error)](ctx.options, funcs.CaseSplit); ok {
+		return fn(s)
+	}
+	words := splitWords(s)
+	if words == nil {
+		return []string{}, nil
+	}
+	return words, nil
+}
+
+// Camel converts s to camelCase.
+//
+// Example:
+//
+//	{{ case.Camel "HTTP server_id" }} // Output: httpServerId
+func (ctx Case) Camel(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseCamel]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CaseCamel}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CaseCamel); ok {
+		return fn(s)
+	}
+	return joinWords(s, "", func(i int, word string) string {
+		if i == 0 {
+			return strings.ToLower(word)
+		}
+		return titleWord(word)
+	}), nil
+}
+
+// Pascal converts s to PascalCase.
+//
+// Example:
+//
+//	{{ case.Pascal "http_server-id" }} // Output: HttpServerId
+func (ctx Case) Pascal(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CasePascal]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CasePascal}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CasePascal); ok {
+		return fn(s)
+	}
+	return joinWords(s, "", func(_ int, word string) string {
+		return titleWord(word)
+	}), nil
+}
+
+// Snake converts s to snake_case.
+//
+// Example:
+//
+//	{{ case.Snake "HTTPServer" }} // Output: http_server
+func (ctx Case) Snake(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseSnake]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CaseSnake}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CaseSnake); ok {
+		return fn(s)
+	}
+	return joinWords(s, "_", func(_ int, word string) string {
+		return strings.ToLower(word)
+	}), nil
+}
+
+// ScreamingSnake converts s to SCREAMING_SNAKE_CASE.
+//
+// Example:
+//
+//	{{ case.ScreamingSnake "maxRetryCount" }} // Output: MAX_RETRY_COUNT
+func (ctx Case) ScreamingSnake(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseScreamingSnake]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CaseScreamingSnake}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CaseScreamingSnake); ok {
+		return fn(s)
+	}
+	return joinWords(s, "_", func(_ int, word string) string {
+		return strings.ToUpper(word)
+	}), nil
+}
+
+// Kebab converts s to kebab-case.
+//
+// Example:
+//
+//	{{ case.Kebab "userID2Name" }} // Output: user-id2-name
+func (ctx Case) Kebab(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseKebab]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CaseKebab}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CaseKebab); ok {
+		return fn(s)
+	}
+	return joinWords(s, "-", func(_ int, word string) string {
+		return strings.ToLower(word)
+	}), nil
+}
+
+// Title converts s to Title Case, words are separated by spaces.
+//
+// Example:
+//
+//	{{ case.Title "user_first_name" }} // Output: User First Name
+func (ctx Case) Title(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseTitle]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CaseTitle}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CaseTitle); ok {
+		return fn(s)
+	}
+	return joinWords(s, " ", func(_ int, word string) string {
+		return titleWord(word)
+	}), nil
+}
+
+// Sentence converts s to Sentence case, words are separated by spaces.
+//
+// Example:
+//
+//	{{ case.Sentence "userFirstName" }} // Output: User first name
+func (ctx Case) Sentence(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CaseSentence]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CaseSentence}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.CaseSentence); ok {
+		return fn(s)
+	}
+	return joinWords(s, " ", func(i int, word string) string {
+		if i == 0 {
+			return titleWord(word)
+		}
+		return strings.ToLower(word)
+	}), nil
+}
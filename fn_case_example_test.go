@@ -0,0 +1,82 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleCase_Camel() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Camel "HTTP server_id" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: httpServerId
+}
+
+func ExampleCase_Kebab() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Kebab "userID2Name" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: user-id2-name
+}
+
+func ExampleCase_Pascal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Pascal "http_server-id" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: HttpServerId
+}
+
+func ExampleCase_ScreamingSnake() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.ScreamingSnake "maxRetryCount" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: MAX_RETRY_COUNT
+}
+
+func ExampleCase_Sentence() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Sentence "userFirstName" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: User first name
+}
+
+func ExampleCase_Snake() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Snake "HTTPServer" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: http_server
+}
+
+func ExampleCase_Split() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Split "parseHTTPResponse2XXStatus" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [parse HTTP Response2 XX Status]
+}
+
+func ExampleCase_Title() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ case.Title "user_first_name" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: User First Name
+}
+
@@ -0,0 +1,59 @@
+package xtemplate_test
+
+import (
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data string
+		want string
+	}{
+		{name: "split separators", tmpl: `{{ case.Split . }}`, data: "  foo__bar-baz.qux ", want: "[foo bar baz qux]"},
+		{name: "split acronym", tmpl: `{{ case.Split . }}`, data: "XMLHttpRequest", want: "[XML Http Request]"},
+		{name: "split trailing acronym", tmpl: `{{ case.Split . }}`, data: "userID", want: "[user ID]"},
+		{
+			name: "split digits",
+			tmpl: `{{ case.Split . }}`,
+			data: "utf8Encoding HTTP2Server",
+			want: "[utf8 Encoding HTTP2 Server]",
+		},
+		{name: "split empty", tmpl: `{{ len ( case.Split . ) }}`, data: "__", want: "0"},
+		{name: "split unicode", tmpl: `{{ case.Split . }}`, data: "straßeÜberÄnderung", want: "[straße Über Änderung]"},
+		{name: "split combining mark", tmpl: `{{ case.Split . }}`, data: "cafe\u0301Menu", want: "[cafe\u0301 Menu]"},
+		{name: "split caseless", tmpl: `{{ case.Split . }}`, data: "日本語_text", want: "[日本語 text]"},
+		{name: "camel", tmpl: `{{ case.Camel . }}`, data: "HTTPServer", want: "httpServer"},
+		{name: "camel from snake", tmpl: `{{ case.Camel . }}`, data: "max_retry_count", want: "maxRetryCount"},
+		{name: "pascal", tmpl: `{{ case.Pascal . }}`, data: "user id", want: "UserId"},
+		{name: "pascal unicode", tmpl: `{{ case.Pascal . }}`, data: "élan vital", want: "ÉlanVital"},
+		{name: "snake", tmpl: `{{ case.Snake . }}`, data: "HTTPServer", want: "http_server"},
+		{name: "snake digits", tmpl: `{{ case.Snake . }}`, data: "Base64Encode", want: "base64_encode"},
+		{name: "screaming snake", tmpl: `{{ case.ScreamingSnake . }}`, data: "apiKey-v2", want: "API_KEY_V2"},
+		{name: "kebab", tmpl: `{{ case.Kebab . }}`, data: "MyHTMLParser", want: "my-html-parser"},
+		{name: "title", tmpl: `{{ case.Title . }}`, data: "the_quick-brownFox", want: "The Quick Brown Fox"},
+		{name: "title digraph", tmpl: `{{ case.Title . }}`, data: "ǆungla", want: "ǅungla"},
+		{name: "sentence", tmpl: `{{ case.Sentence . }}`, data: "HTTPServerError", want: "Http server error"},
+		{name: "empty", tmpl: `{{ case.Camel . }}`, data: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Case)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
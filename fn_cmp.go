@@ -33,11 +33,18 @@ var ErrOnlyOneSliceIsAllowed = errors.New("only one slice argument is allowed")
 //
 //	{{ cmp.Or ( slice.NewStrings "" "Hello" "World" ) }} // Output: Hello
 //
+// Example 4:
+//
+//	{{ cmp.Or ( decimal.New "0.00" ) ( decimal.New "1.50" ) }} // Output: 1.50
+//
 //nolint:gocognit, gocyclo, cyclop, funlen // cannot be simplified
 func (ctx Cmp) Or(s ...any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.CmpOr]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.CmpOr}
 	}
+	if fn, ok := override[func(...any) (any, error)](ctx.options, funcs.CmpOr); ok {
+		return fn(s...)
+	}
 
 	if len(s) == 0 {
 		return nil, ErrAtLeastOneArgumentIsRequired
@@ -60,6 +67,17 @@ func (ctx Cmp) Or(s ...any) (any, error) {
 		return cmp.Or(v...), nil
 	case string:
 		return cmp.Or(toStrings(s)...), nil
+	case DecimalValue:
+		for _, v := range s {
+			d, err := toDecimal(v)
+			if err != nil {
+				return nil, err
+			}
+			if !d.IsZero() {
+				return d, nil
+			}
+		}
+		return DecimalValue{}, nil
 	case int:
 		v, err := toInts[int](s, math.MinInt, math.MaxInt)
 		if err != nil {
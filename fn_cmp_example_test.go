@@ -35,3 +35,12 @@ func ExampleCmp_Or_third() {
 	fmt.Println(s) // Output: Hello
 }
 
+func ExampleCmp_Or_fourth() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ cmp.Or ( decimal.New "0.00" ) ( decimal.New "1.50" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.50
+}
+
@@ -60,6 +60,9 @@ func (ctx Conv) ToBool(in any) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToBool]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.ConvToBool}
 	}
+	if fn, ok := override[func(any) (bool, error)](ctx.options, funcs.ConvToBool); ok {
+		return fn(in)
+	}
 	return toBool(in), nil
 }
 
@@ -73,6 +76,9 @@ func (ctx Conv) ToBools(in []any) ([]bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToBools]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToBools}
 	}
+	if fn, ok := override[func([]any) ([]bool, error)](ctx.options, funcs.ConvToBools); ok {
+		return fn(in)
+	}
 	return toBools(in), nil
 }
 
@@ -110,6 +116,9 @@ func (ctx Conv) ToString(in any) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToString]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.ConvToString}
 	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.ConvToString); ok {
+		return fn(in)
+	}
 
 	return toString(in), nil
 }
@@ -124,6 +133,9 @@ func (ctx Conv) ToStrings(in []any) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToStrings]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToStrings}
 	}
+	if fn, ok := override[func([]any) ([]string, error)](ctx.options, funcs.ConvToStrings); ok {
+		return fn(in)
+	}
 	return toStrings(in), nil
 }
 
@@ -196,6 +208,9 @@ func (ctx Conv) ToFloat64(v any) (float64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToFloat64]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToFloat64}
 	}
+	if fn, ok := override[func(any) (float64, error)](ctx.options, funcs.ConvToFloat64); ok {
+		return fn(v)
+	}
 	return toFloat64(v)
 }
 
@@ -209,6 +224,9 @@ func (ctx Conv) ToFloat64s(in []any) ([]float64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToFloat64s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToFloat64s}
 	}
+	if fn, ok := override[func([]any) ([]float64, error)](ctx.options, funcs.ConvToFloat64s); ok {
+		return fn(in)
+	}
 
 	return toFloat64s(in)
 }
@@ -246,6 +264,9 @@ func (ctx Conv) ToFloat32(v any) (float32, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToFloat32]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToFloat32}
 	}
+	if fn, ok := override[func(any) (float32, error)](ctx.options, funcs.ConvToFloat32); ok {
+		return fn(v)
+	}
 	return toFloat32(v)
 }
 
@@ -259,6 +280,9 @@ func (ctx Conv) ToFloat32s(in []any) ([]float32, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToFloat32s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToFloat32s}
 	}
+	if fn, ok := override[func([]any) ([]float32, error)](ctx.options, funcs.ConvToFloat32s); ok {
+		return fn(in)
+	}
 
 	return toFloat32s(in)
 }
@@ -339,6 +363,9 @@ func (ctx Conv) ToInt64(v any) (int64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt64]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToInt64}
 	}
+	if fn, ok := override[func(any) (int64, error)](ctx.options, funcs.ConvToInt64); ok {
+		return fn(v)
+	}
 	i, err := toInt64(v)
 	if err != nil {
 		return 0, err
@@ -355,6 +382,9 @@ func (ctx Conv) ToInt64s(in []any) ([]int64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt64s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToInt64s}
 	}
+	if fn, ok := override[func([]any) ([]int64, error)](ctx.options, funcs.ConvToInt64s); ok {
+		return fn(in)
+	}
 
 	return toInt64s(in)
 }
@@ -395,6 +425,9 @@ func (ctx Conv) ToInt8(v any) (int8, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt8]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToInt8}
 	}
+	if fn, ok := override[func(any) (int8, error)](ctx.options, funcs.ConvToInt8); ok {
+		return fn(v)
+	}
 	i, err := toInt[int8](v, math.MinInt8, math.MaxInt8)
 	if err != nil {
 		return 0, err
@@ -412,6 +445,9 @@ func (ctx Conv) ToInt8s(in []any) ([]int8, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt8s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToInt8s}
 	}
+	if fn, ok := override[func([]any) ([]int8, error)](ctx.options, funcs.ConvToInt8s); ok {
+		return fn(in)
+	}
 	return toInts[int8](in, math.MinInt8, math.MaxInt8)
 }
 
@@ -424,6 +460,9 @@ func (ctx Conv) ToInt16(v any) (int16, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt16]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToInt16}
 	}
+	if fn, ok := override[func(any) (int16, error)](ctx.options, funcs.ConvToInt16); ok {
+		return fn(v)
+	}
 	i, err := toInt[int16](v, math.MinInt16, math.MaxInt16)
 	if err != nil {
 		return 0, err
@@ -441,6 +480,9 @@ func (ctx Conv) ToInt16s(in []any) ([]int16, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt16s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToInt16s}
 	}
+	if fn, ok := override[func([]any) ([]int16, error)](ctx.options, funcs.ConvToInt16s); ok {
+		return fn(in)
+	}
 	return toInts[int16](in, math.MinInt16, math.MaxInt16)
 }
 
@@ -453,6 +495,9 @@ func (ctx Conv) ToInt32(v any) (int32, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt32]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToInt32}
 	}
+	if fn, ok := override[func(any) (int32, error)](ctx.options, funcs.ConvToInt32); ok {
+		return fn(v)
+	}
 	i, err := toInt[int32](v, math.MinInt32, math.MaxInt32)
 	if err != nil {
 		return 0, err
@@ -470,6 +515,9 @@ func (ctx Conv) ToInt32s(in []any) ([]int32, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt32s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToInt32s}
 	}
+	if fn, ok := override[func([]any) ([]int32, error)](ctx.options, funcs.ConvToInt32s); ok {
+		return fn(in)
+	}
 	return toInts[int32](in, math.MinInt32, math.MaxInt32)
 }
 
@@ -482,6 +530,9 @@ func (ctx Conv) ToInt(v any) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInt]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToInt}
 	}
+	if fn, ok := override[func(any) (int, error)](ctx.options, funcs.ConvToInt); ok {
+		return fn(v)
+	}
 	i, err := toInt[int](v, math.MinInt, math.MaxInt)
 	if err != nil {
 		return 0, err
@@ -499,6 +550,9 @@ func (ctx Conv) ToInts(in []any) ([]int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToInts]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToInts}
 	}
+	if fn, ok := override[func([]any) ([]int, error)](ctx.options, funcs.ConvToInts); ok {
+		return fn(in)
+	}
 	return toInts[int](in, math.MinInt, math.MaxInt)
 }
 
@@ -578,6 +632,9 @@ func (ctx Conv) ToUint64(v any) (uint64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint64]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToUint64}
 	}
+	if fn, ok := override[func(any) (uint64, error)](ctx.options, funcs.ConvToUint64); ok {
+		return fn(v)
+	}
 	i, err := toUint64(v)
 	if err != nil {
 		return 0, err
@@ -594,6 +651,9 @@ func (ctx Conv) ToUint64s(in []any) ([]uint64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint64s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToUint64s}
 	}
+	if fn, ok := override[func([]any) ([]uint64, error)](ctx.options, funcs.ConvToUint64s); ok {
+		return fn(in)
+	}
 
 	return toUint64s(in)
 }
@@ -634,6 +694,9 @@ func (ctx Conv) ToUint8(v any) (uint8, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint8]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToUint8}
 	}
+	if fn, ok := override[func(any) (uint8, error)](ctx.options, funcs.ConvToUint8); ok {
+		return fn(v)
+	}
 	i, err := toUint[uint8](v, math.MaxUint8)
 	if err != nil {
 		return 0, err
@@ -651,6 +714,9 @@ func (ctx Conv) ToUint8s(in []any) ([]uint8, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint8s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToUint8s}
 	}
+	if fn, ok := override[func([]any) ([]uint8, error)](ctx.options, funcs.ConvToUint8s); ok {
+		return fn(in)
+	}
 	return toUints[uint8](in, math.MaxUint8)
 }
 
@@ -663,6 +729,9 @@ func (ctx Conv) ToUint16(v any) (uint16, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint16]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToUint16}
 	}
+	if fn, ok := override[func(any) (uint16, error)](ctx.options, funcs.ConvToUint16); ok {
+		return fn(v)
+	}
 	i, err := toUint[uint16](v, math.MaxUint16)
 	if err != nil {
 		return 0, err
@@ -680,6 +749,9 @@ func (ctx Conv) ToUint16s(in []any) ([]uint16, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint16s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToUint16s}
 	}
+	if fn, ok := override[func([]any) ([]uint16, error)](ctx.options, funcs.ConvToUint16s); ok {
+		return fn(in)
+	}
 	return toUints[uint16](in, math.MaxUint16)
 }
 
@@ -692,6 +764,9 @@ func (ctx Conv) ToUint32(v any) (uint32, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint32]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToUint32}
 	}
+	if fn, ok := override[func(any) (uint32, error)](ctx.options, funcs.ConvToUint32); ok {
+		return fn(v)
+	}
 	i, err := toUint[uint32](v, math.MaxUint32)
 	if err != nil {
 		return 0, err
@@ -709,6 +784,9 @@ func (ctx Conv) ToUint32s(in []any) ([]uint32, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint32s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToUint32s}
 	}
+	if fn, ok := override[func([]any) ([]uint32, error)](ctx.options, funcs.ConvToUint32s); ok {
+		return fn(in)
+	}
 	return toUints[uint32](in, math.MaxUint32)
 }
 
@@ -721,6 +799,9 @@ func (ctx Conv) ToUint(v any) (uint, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUint]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.ConvToUint}
 	}
+	if fn, ok := override[func(any) (uint, error)](ctx.options, funcs.ConvToUint); ok {
+		return fn(v)
+	}
 	i, err := toUint[uint](v, math.MaxUint)
 	if err != nil {
 		return 0, err
@@ -738,5 +819,8 @@ func (ctx Conv) ToUints(in []any) ([]uint, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.ConvToUints]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.ConvToUints}
 	}
+	if fn, ok := override[func([]any) ([]uint, error)](ctx.options, funcs.ConvToUints); ok {
+		return fn(in)
+	}
 	return toUints[uint](in, math.MaxUint)
 }
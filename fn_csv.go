@@ -0,0 +1,298 @@
+package xtemplate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// CSV provides functions to parse and encode comma-separated values.
+//
+// All functions accept an optional dict to configure the format:
+//
+//   - "delimiter": the field delimiter, defaults to ","
+//   - "comment": lines starting with this character are ignored when parsing
+//   - "lazyQuotes": allow quotes in unquoted fields and non-doubled quotes in quoted fields when parsing
+//   - "crlf": use \r\n as line terminator when encoding
+//   - "columns": the columns and their order when encoding dicts
+//
+// Malformed input returns a *SyntaxError with the line and column of the error.
+type CSV rootContext
+
+// InvalidCSVOptionError is returned when an unknown option or an invalid option value is passed.
+type InvalidCSVOptionError struct {
+	Option string
+}
+
+func (e *InvalidCSVOptionError) Error() string {
+	return fmt.Sprintf("invalid csv option %q", e.Option)
+}
+
+type csvConfig struct {
+	delimiter  rune
+	comment    rune
+	lazyQuotes bool
+	crlf       bool
+	columns    []string
+}
+
+func csvRune(option string, v any) (rune, error) {
+	s, ok := v.(string)
+	if !ok || utf8.RuneCountInString(s) != 1 {
+		return 0, &InvalidCSVOptionError{Option: option}
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
+
+//nolint:cyclop // cannot be simplified
+func newCSVConfig(config []map[any]any) (*csvConfig, error) {
+	if len(config) > 1 {
+		return nil, OnlyOneArgumentIsAllowedError{}
+	}
+	cfg := &csvConfig{delimiter: ',', comment: 0, lazyQuotes: false, crlf: false, columns: nil}
+	if len(config) == 0 {
+		return cfg, nil
+	}
+	// the options are checked in a stable order, so the same invalid options always report the same error
+	keys := make([]any, 0, len(config[0]))
+	for k := range config[0] {
+		keys = append(keys, k)
+	}
+	sortAny(keys)
+	for _, k := range keys {
+		v := config[0][k]
+		option := toString(k)
+		var err error
+		switch option {
+		case "delimiter":
+			cfg.delimiter, err = csvRune(option, v)
+		case "comment":
+			cfg.comment, err = csvRune(option, v)
+		case "lazyQuotes", "crlf":
+			b, ok := v.(bool)
+			if !ok {
+				return nil, &InvalidCSVOptionError{Option: option}
+			}
+			if option == "crlf" {
+				cfg.crlf = b
+			} else {
+				cfg.lazyQuotes = b
+			}
+		case "columns":
+			var columns []any
+			if columns, err = sliceToAny(v); err != nil {
+				return nil, &InvalidCSVOptionError{Option: option}
+			}
+			cfg.columns = toStrings(columns)
+		default:
+			return nil, &InvalidCSVOptionError{Option: option}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func parseCSV(data any, config []map[any]any) ([][]string, error) {
+	b, err := toBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := newCSVConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	r := csv.NewReader(strings.NewReader(string(b)))
+	r.Comma = cfg.delimiter
+	r.Comment = cfg.comment
+	r.LazyQuotes = cfg.lazyQuotes
+	records, err := r.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &SyntaxError{
+				Format: "csv",
+				Line:   parseErr.Line,
+				Column: parseErr.Column,
+				Msg:    parseErr.Err.Error(),
+			}
+		}
+		return nil, err
+	}
+	if records == nil {
+		records = [][]string{}
+	}
+	return records, nil
+}
+
+// Parse parses data (a string or []byte) and returns its records.
+// All records must have the same number of fields.
+//
+// Example 1:
+//
+//	{{ csv.Parse "name,age\nAlice,30" }} // Output: [[name age] [Alice 30]]
+//
+// Example 2:
+//
+//	{{ csv.Parse "a;b\n# comment\nc;d" ( dict.New "delimiter" ";" "comment" "#" ) }} // Output: [[a b] [c d]]
+func (ctx CSV) Parse(data any, config ...map[any]any) ([][]string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CSVParse]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.CSVParse}
+	}
+	if fn, ok := override[func(any, ...map[any]any) ([][]string, error)](ctx.options, funcs.CSVParse); ok {
+		return fn(data, config...)
+	}
+	return parseCSV(data, config)
+}
+
+// ParseWithHeader parses data (a string or []byte) and returns a dict for every record,
+// keyed by the names in the first record.
+//
+// Example:
+//
+//	{{ range csv.ParseWithHeader "name,age\nAlice,30\nBob,25" }}{{ .name }} {{ end }} // Output: Alice Bob
+func (ctx CSV) ParseWithHeader(data any, config ...map[any]any) ([]map[any]any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CSVParseWithHeader]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.CSVParseWithHeader}
+	}
+	if fn, ok := override[func(any, ...map[any]any) ([]map[any]any, error)](ctx.options, funcs.CSVParseWithHeader); ok {
+		return fn(data, config...)
+	}
+	records, err := parseCSV(data, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return []map[any]any{}, nil
+	}
+	header := records[0]
+	for i, name := range header {
+		if slices.Contains(header[:i], name) {
+			return nil, &SyntaxError{
+				Format: "csv",
+				Line:   1,
+				Column: i + 1,
+				Msg:    fmt.Sprintf("duplicate column %q", name),
+			}
+		}
+	}
+	out := make([]map[any]any, len(records)-1)
+	for i, record := range records[1:] {
+		m := make(map[any]any, len(header))
+		for j, name := range header {
+			m[name] = record[j]
+		}
+		out[i] = m
+	}
+	return out, nil
+}
+
+// Encode returns rows as CSV, fields are quoted when needed.
+// rows is a slice of rows or a slice of dicts. For dicts a header record is written first,
+// the columns are the sorted keys of all dicts unless the "columns" option is set.
+//
+// Example 1:
+//
+//	{{ printf "%q" ( csv.Encode ( slice.New ( slice.New "a" "b,c" 1 ) ) ) }} // Output: "a,\"b,c\",1\n"
+//
+// Example 2:
+//
+//	{{ printf "%q" ( csv.Encode ( slice.New ( dict.New "name" "Al" "age" 3 ) ) ) }} // Output: "age,name\n3,Al\n"
+func (ctx CSV) Encode(rows any, config ...map[any]any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.CSVEncode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.CSVEncode}
+	}
+	if fn, ok := override[func(any, ...map[any]any) (string, error)](ctx.options, funcs.CSVEncode); ok {
+		return fn(rows, config...)
+	}
+	cfg, err := newCSVConfig(config)
+	if err != nil {
+		return "", err
+	}
+	records, err := csvRecords(rows, cfg.columns)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = cfg.delimiter
+	w.UseCRLF = cfg.crlf
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// csvRecords converts a slice of rows or a slice of dicts to records.
+func csvRecords(rows any, columns []string) ([][]string, error) {
+	items, err := sliceToAny(rows)
+	if err != nil {
+		return nil, err
+	}
+	var records [][]string
+	var dicts []reflect.Value
+	for _, item := range items {
+		rv := reflect.ValueOf(item)
+		switch rv.Kind() {
+		case reflect.Map:
+			dicts = append(dicts, rv)
+		case reflect.Slice, reflect.Array:
+			fields, err := sliceToAny(item)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, toStrings(fields))
+		default:
+			return nil, ErrArgNotSlice
+		}
+	}
+	if len(dicts) == 0 {
+		return records, nil
+	}
+	if len(records) > 0 {
+		return nil, ErrInvalidArgument
+	}
+	if columns == nil {
+		var keys []any
+		for _, d := range dicts {
+			for _, k := range d.MapKeys() {
+				if !slices.Contains(keys, k.Interface()) {
+					keys = append(keys, k.Interface())
+				}
+			}
+		}
+		sortAny(keys)
+		columns = toStrings(keys)
+	}
+	records = append(records, columns)
+	for _, d := range dicts {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			if v := csvMapIndex(d, column); v.IsValid() {
+				record[i] = toString(v.Interface())
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// csvMapIndex returns the value for the column in a map with string or any keys.
+func csvMapIndex(m reflect.Value, column string) reflect.Value {
+	key := reflect.ValueOf(column)
+	if !key.Type().AssignableTo(m.Type().Key()) {
+		if !key.Type().ConvertibleTo(m.Type().Key()) {
+			return reflect.Value{}
+		}
+		key = key.Convert(m.Type().Key())
+	}
+	return m.MapIndex(key)
+}
@@ -0,0 +1,55 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleCSV_Encode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ printf "%q" ( csv.Encode ( slice.New ( slice.New "a" "b,c" 1 ) ) ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "a,\"b,c\",1\n"
+}
+
+func ExampleCSV_Encode_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ printf "%q" ( csv.Encode ( slice.New ( dict.New "name" "Al" "age" 3 ) ) ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "age,name\n3,Al\n"
+}
+
+func ExampleCSV_Parse() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ csv.Parse "name,age\nAlice,30" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [[name age] [Alice 30]]
+}
+
+func ExampleCSV_Parse_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ csv.Parse "a;b\n# comment\nc;d" ( dict.New "delimiter" ";" "comment" "#" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [[a b] [c d]]
+}
+
+func ExampleCSV_ParseWithHeader() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ range csv.ParseWithHeader "name,age\nAlice,30\nBob,25" }}{{ .name }} {{ end }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Alice Bob
+}
+
@@ -0,0 +1,199 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestCSV_Parse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data string
+		want string
+	}{
+		{
+			name: "quoted fields",
+			tmpl: `{{ printf "%q" ( csv.Parse . ) }}`,
+			data: "a,\"b,c\",\"d \"\"e\"\"\"\n\"multi\nline\",2,3\n",
+			want: `[["a" "b,c" "d \"e\""] ["multi\nline" "2" "3"]]`,
+		},
+		{
+			name: "delimiter and comment",
+			tmpl: `{{ csv.Parse . ( dict.New "delimiter" "\t" "comment" "#" ) }}`,
+			data: "# header\na\tb\nc\td\n",
+			want: "[[a b] [c d]]",
+		},
+		{
+			name: "lazy quotes",
+			tmpl: `{{ csv.Parse . ( dict.New "lazyQuotes" true ) }}`,
+			data: "a \"quoted\" word,b\n",
+			want: `[[a "quoted" word b]]`,
+		},
+		{
+			name: "empty",
+			tmpl: `{{ len ( csv.Parse . ) }}`,
+			data: "",
+			want: "0",
+		},
+		{
+			name: "with header",
+			tmpl: `{{ range csv.ParseWithHeader . }}{{ .name }}:{{ .age }};{{ end }}`,
+			data: "name,age\nAlice,30\nBob,25\n",
+			want: "Alice:30;Bob:25;",
+		},
+		{
+			name: "with header and dict functions",
+			tmpl: `{{ dict.HasKey ( index ( csv.ParseWithHeader . ) 0 ) "age" }}`,
+			data: "name,age\nAlice,30\n",
+			want: "true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Safe)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSV_ParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		data    string
+		wantErr xtemplate.SyntaxError
+	}{
+		{
+			name:    "bare quote",
+			tmpl:    `{{ csv.Parse . }}`,
+			data:    "a,b\nc,d\"e\n",
+			wantErr: xtemplate.SyntaxError{Format: "csv", Line: 2, Column: 4, Msg: `bare " in non-quoted-field`},
+		},
+		{
+			name:    "wrong number of fields",
+			tmpl:    `{{ csv.ParseWithHeader . }}`,
+			data:    "a,b\n1,2\n3\n",
+			wantErr: xtemplate.SyntaxError{Format: "csv", Line: 3, Column: 1, Msg: "wrong number of fields"},
+		},
+		{
+			name:    "duplicate column",
+			tmpl:    `{{ csv.ParseWithHeader . }}`,
+			data:    "a,b,a\n1,2,3\n",
+			wantErr: xtemplate.SyntaxError{Format: "csv", Line: 1, Column: 3, Msg: `duplicate column "a"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Safe)
+			var syntaxErr *xtemplate.SyntaxError
+			if !errors.As(err, &syntaxErr) || *syntaxErr != tt.wantErr {
+				t.Errorf("QuickExecute() error = %v, want %v", err, &tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSV_Encode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{
+			name: "rows",
+			tmpl: `{{ csv.Encode . }}`,
+			data: [][]string{{"a", "b,c", `d "e"`}, {"multi\nline", "", " x"}},
+			want: "a,\"b,c\",\"d \"\"e\"\"\"\n\"multi\nline\",,\" x\"\n",
+		},
+		{
+			name: "delimiter and crlf",
+			tmpl: `{{ csv.Encode . ( dict.New "delimiter" ";" "crlf" true ) }}`,
+			data: [][]any{{"a", 1}, {"b;c", true}},
+			want: "a;1\r\n\"b;c\";true\r\n",
+		},
+		{
+			name: "dicts",
+			tmpl: `{{ csv.Encode . }}`,
+			data: []map[string]any{{"name": "Alice", "age": 30}, {"name": "Bob", "city": "Berlin"}},
+			want: "age,city,name\n30,,Alice\n,Berlin,Bob\n",
+		},
+		{
+			name: "dicts with columns",
+			tmpl: `{{ csv.Encode . ( dict.New "columns" ( slice.New "name" "age" ) ) }}`,
+			data: []map[any]any{{"name": "Alice", "age": 30, "city": "Berlin"}},
+			want: "name,age\nAlice,30\n",
+		},
+		{
+			name: "round trip",
+			tmpl: `{{ csv.Encode ( csv.Parse ( csv.Encode . ) ) }}`,
+			data: [][]string{{"a\"b", "c\nd"}},
+			want: "\"a\"\"b\",\"c\nd\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Safe)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSV_InvalidOption(t *testing.T) {
+	t.Parallel()
+
+	for _, tmpl := range []string{
+		`{{ csv.Parse "a" ( dict.New "delimiter" ";;" ) }}`,
+		`{{ csv.Parse "a" ( dict.New "lazyQuotes" "yes" ) }}`,
+		`{{ csv.Encode ( slice.New ) ( dict.New "unknown" 1 ) }}`,
+	} {
+		_, err := xtemplate.QuickExecute(tmpl, nil, funcs.Safe)
+		var optionErr *xtemplate.InvalidCSVOptionError
+		if !errors.As(err, &optionErr) {
+			t.Errorf("QuickExecute(%q) error = %v, want InvalidCSVOptionError", tmpl, err)
+		}
+	}
+}
+
+func TestCSV_InvalidOptionOrder(t *testing.T) {
+	t.Parallel()
+
+	// the first invalid option in sorted order is reported, regardless of the map order
+	for range 20 {
+		_, err := xtemplate.QuickExecute(`{{ csv.Parse "a" ( dict.New "zzz" 1 "crlf" "no" "aaa" 2 "delimiter" "" ) }}`,
+			nil, funcs.Safe)
+		var optionErr *xtemplate.InvalidCSVOptionError
+		if !errors.As(err, &optionErr) || optionErr.Option != "aaa" {
+			t.Fatalf("QuickExecute() error = %v, want InvalidCSVOptionError for aaa", err)
+		}
+	}
+}
@@ -0,0 +1,548 @@
+package xtemplate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Decimal provides arbitrary-precision decimal arithmetic, e.g. for money.
+//
+// The functions accept DecimalValue values, decimal strings (e.g. "12.50") and numbers.
+// Decimal strings use a dot as decimal separator and must not contain thousands separators, so "1,50" is invalid.
+// Floats are converted using their shortest decimal representation, so 0.1 becomes exactly 0.1.
+// Decimals are limited to 1000 digits.
+//
+// Rounding modes:
+//   - "half-even": round to nearest, ties to even (banker's rounding), this is the default
+//   - "half-up": round to nearest, ties away from zero
+//   - "down": round towards zero (truncate)
+type Decimal rootContext
+
+// maxDecimalScale limits the number of decimal places to keep the size of the numbers reasonable.
+const maxDecimalScale = 1000
+
+// maxDecimalDigits limits the total number of digits of a decimal to keep the size of the numbers reasonable.
+const maxDecimalDigits = 1000
+
+// ErrInvalidDecimal is returned when a value cannot be converted to a decimal.
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// ErrDecimalScaleOutOfRange is returned when a scale is negative or too large.
+var ErrDecimalScaleOutOfRange = errors.New("decimal scale out of range")
+
+// ErrDecimalOutOfRange is returned when a decimal or the result of an operation has too many digits.
+var ErrDecimalOutOfRange = errors.New("decimal out of range")
+
+// maxDecimalUnscaled is the smallest unscaled value that has more than maxDecimalDigits digits.
+//
+//nolint:gochecknoglobals // lookup table
+var maxDecimalUnscaled = pow10(maxDecimalDigits)
+
+// UnknownRoundingModeError is returned when an unknown rounding mode is used.
+type UnknownRoundingModeError struct {
+	Mode string
+}
+
+func (e *UnknownRoundingModeError) Error() string {
+	return fmt.Sprintf("unknown rounding mode %q", e.Mode)
+}
+
+// DecimalValue is an arbitrary-precision decimal number.
+// The zero value represents 0.
+type DecimalValue struct {
+	unscaled *big.Int
+	scale    int
+}
+
+func (d DecimalValue) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// String returns the decimal in plain notation with all of its decimal places, e.g. "12.50".
+func (d DecimalValue) String() string {
+	i := d.int()
+	digits := new(big.Int).Abs(i).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if i.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON encodes the decimal as a JSON number.
+func (d DecimalValue) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Cmp compares d and e and returns -1 if d < e, 0 if d == e and +1 if d > e.
+func (d DecimalValue) Cmp(e DecimalValue) int {
+	scale := max(d.scale, e.scale)
+	return d.rescale(scale).Cmp(e.rescale(scale))
+}
+
+// IsZero reports whether d is 0.
+func (d DecimalValue) IsZero() bool {
+	return d.int().Sign() == 0
+}
+
+// rescale returns the unscaled value of d with the given scale, scale must be greater or equal to d.scale.
+func (d DecimalValue) rescale(scale int) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+func pow10(n int) *big.Int {
+	//nolint:mnd // base 10
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// checkDigits returns d or ErrDecimalOutOfRange if d has more than maxDecimalDigits digits.
+func checkDigits(d DecimalValue) (DecimalValue, error) {
+	if d.int().CmpAbs(maxDecimalUnscaled) >= 0 {
+		return DecimalValue{}, ErrDecimalOutOfRange
+	}
+	return d, nil
+}
+
+func parseDecimal(s string) (DecimalValue, error) {
+	str := strings.TrimSpace(s)
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(str), "e")
+	exp := 0
+	if hasExponent {
+		var err error
+		exp, err = strconv.Atoi(exponent)
+		if err != nil || exp > maxDecimalScale || exp < -maxDecimalScale {
+			return DecimalValue{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" || strings.ContainsAny(digits[1:], "+-") {
+		return DecimalValue{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	// sign and leading zeros aside, a longer string cannot be in range
+	if len(strings.TrimLeft(digits, "+-0")) > maxDecimalDigits {
+		return DecimalValue{}, ErrDecimalOutOfRange
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return DecimalValue{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	if scale > maxDecimalScale {
+		return DecimalValue{}, ErrDecimalScaleOutOfRange
+	}
+	return checkDigits(DecimalValue{unscaled: unscaled, scale: scale})
+}
+
+func toDecimal(v any) (DecimalValue, error) {
+	switch tv := v.(type) {
+	case DecimalValue:
+		return tv, nil
+	case *DecimalValue:
+		if tv != nil {
+			return *tv, nil
+		}
+	case string:
+		return parseDecimal(tv)
+	case *big.Int:
+		if tv != nil {
+			return checkDigits(DecimalValue{unscaled: new(big.Int).Set(tv), scale: 0})
+		}
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(v))
+	switch val.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return DecimalValue{unscaled: big.NewInt(val.Int()), scale: 0}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return DecimalValue{unscaled: new(big.Int).SetUint64(val.Uint()), scale: 0}, nil
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return DecimalValue{}, fmt.Errorf("%w: %v", ErrInvalidDecimal, f)
+		}
+		bitSize := 64
+		if val.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		return parseDecimal(strconv.FormatFloat(f, 'f', -1, bitSize))
+	default:
+		return DecimalValue{}, fmt.Errorf("%w: %v", ErrInvalidDecimal, v)
+	}
+}
+
+func toDecimals(in []any) ([]DecimalValue, error) {
+	// allow a single slice argument
+	if len(in) == 1 {
+		if sl := reflect.ValueOf(in[0]); sl.Kind() == reflect.Slice {
+			in = make([]any, sl.Len())
+			for i := range in {
+				in[i] = sl.Index(i).Interface()
+			}
+		}
+	}
+	out := make([]DecimalValue, len(in))
+	for i, v := range in {
+		d, err := toDecimal(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+type roundingMode int
+
+const (
+	roundHalfEven roundingMode = iota
+	roundHalfUp
+	roundDown
+)
+
+func toRoundingMode(mode []string) (roundingMode, error) {
+	if len(mode) > 1 {
+		return 0, OnlyOneArgumentIsAllowedError{}
+	}
+	if len(mode) == 0 {
+		return roundHalfEven, nil
+	}
+	switch mode[0] {
+	case "half-even", "":
+		return roundHalfEven, nil
+	case "half-up":
+		return roundHalfUp, nil
+	case "down":
+		return roundDown, nil
+	default:
+		return 0, &UnknownRoundingModeError{Mode: mode[0]}
+	}
+}
+
+// roundQuo returns num / den rounded with the given rounding mode.
+func roundQuo(num, den *big.Int, mode roundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || mode == roundDown {
+		return q
+	}
+	// compare 2*|r| with |den|
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	c := half.Cmp(new(big.Int).Abs(den))
+	away := c > 0 || (c == 0 && (mode == roundHalfUp || q.Bit(0) == 1))
+	if away {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func roundDecimal(d DecimalValue, scale int, mode roundingMode) (DecimalValue, error) {
+	if scale < 0 || scale > maxDecimalScale {
+		return DecimalValue{}, ErrDecimalScaleOutOfRange
+	}
+	if scale >= d.scale {
+		return checkDigits(DecimalValue{unscaled: d.rescale(scale), scale: scale})
+	}
+	return checkDigits(DecimalValue{unscaled: roundQuo(d.int(), pow10(d.scale-scale), mode), scale: scale})
+}
+
+// New converts a decimal string or a number to a decimal.
+//
+// Example 1:
+//
+//	{{ decimal.New "12.50" }} // Output: 12.50
+//
+// Example 2:
+//
+//	{{ decimal.New 0.1 }} // Output: 0.1
+func (ctx Decimal) New(v any) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalNew]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalNew}
+	}
+	if fn, ok := override[func(any) (DecimalValue, error)](ctx.options, funcs.DecimalNew); ok {
+		return fn(v)
+	}
+	return toDecimal(v)
+}
+
+func (ctx Decimal) binary(a, b any, op func(x, y DecimalValue) DecimalValue) (DecimalValue, error) {
+	x, err := toDecimal(a)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	return checkDigits(op(x, y))
+}
+
+// Add returns a + b.
+//
+// Example:
+//
+//	{{ decimal.Add 0.1 0.2 }} // Output: 0.3
+func (ctx Decimal) Add(a, b any) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalAdd]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalAdd}
+	}
+	if fn, ok := override[func(any, any) (DecimalValue, error)](ctx.options, funcs.DecimalAdd); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(a, b, func(x, y DecimalValue) DecimalValue {
+		scale := max(x.scale, y.scale)
+		return DecimalValue{unscaled: new(big.Int).Add(x.rescale(scale), y.rescale(scale)), scale: scale}
+	})
+}
+
+// Sub returns a - b.
+//
+// Example:
+//
+//	{{ decimal.Sub "10.00" "0.01" }} // Output: 9.99
+func (ctx Decimal) Sub(a, b any) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalSub]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalSub}
+	}
+	if fn, ok := override[func(any, any) (DecimalValue, error)](ctx.options, funcs.DecimalSub); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(a, b, func(x, y DecimalValue) DecimalValue {
+		scale := max(x.scale, y.scale)
+		return DecimalValue{unscaled: new(big.Int).Sub(x.rescale(scale), y.rescale(scale)), scale: scale}
+	})
+}
+
+// Mul returns a * b. The scale of the result is the sum of the scales of a and b.
+//
+// Example:
+//
+//	{{ decimal.Mul "19.99" 3 }} // Output: 59.97
+func (ctx Decimal) Mul(a, b any) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalMul]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalMul}
+	}
+	if fn, ok := override[func(any, any) (DecimalValue, error)](ctx.options, funcs.DecimalMul); ok {
+		return fn(a, b)
+	}
+	x, err := toDecimal(a)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	if x.scale+y.scale > maxDecimalScale {
+		return DecimalValue{}, ErrDecimalScaleOutOfRange
+	}
+	return checkDigits(DecimalValue{unscaled: new(big.Int).Mul(x.int(), y.int()), scale: x.scale + y.scale})
+}
+
+// Div returns a / b rounded to scale decimal places using the given rounding mode (default "half-even").
+//
+// Example 1:
+//
+//	{{ decimal.Div 10 3 2 }} // Output: 3.33
+//
+// Example 2:
+//
+//	{{ decimal.Div 1 8 2 "half-up" }} // Output: 0.13
+//
+// Example 3:
+//
+//	{{ decimal.Div 1 8 2 "half-even" }} // Output: 0.12
+func (ctx Decimal) Div(a, b any, scale int, mode ...string) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalDiv]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalDiv}
+	}
+	if fn, ok := override[func(any, any, int, ...string) (DecimalValue, error)](ctx.options, funcs.DecimalDiv); ok {
+		return fn(a, b, scale, mode...)
+	}
+	rm, err := toRoundingMode(mode)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	if scale < 0 || scale > maxDecimalScale {
+		return DecimalValue{}, ErrDecimalScaleOutOfRange
+	}
+	x, err := toDecimal(a)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	if y.IsZero() {
+		return DecimalValue{}, ErrDivisionByZero
+	}
+	// x.unscaled / 10^x.scale / (y.unscaled / 10^y.scale) * 10^scale
+	num := new(big.Int).Set(x.int())
+	den := new(big.Int).Set(y.int())
+	if shift := scale + y.scale - x.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return checkDigits(DecimalValue{unscaled: roundQuo(num, den, rm), scale: scale})
+}
+
+// Round returns d rounded to scale decimal places using the given rounding mode (default "half-even").
+//
+// Example 1:
+//
+//	{{ decimal.Round "2.345" 2 }} // Output: 2.34
+//
+// Example 2:
+//
+//	{{ decimal.Round "2.345" 2 "half-up" }} // Output: 2.35
+//
+// Example 3:
+//
+//	{{ decimal.Round "-2.349" 2 "down" }} // Output: -2.34
+func (ctx Decimal) Round(d any, scale int, mode ...string) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalRound]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalRound}
+	}
+	if fn, ok := override[func(any, int, ...string) (DecimalValue, error)](ctx.options, funcs.DecimalRound); ok {
+		return fn(d, scale, mode...)
+	}
+	rm, err := toRoundingMode(mode)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	v, err := toDecimal(d)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	return roundDecimal(v, scale, rm)
+}
+
+// Format returns d formatted with exactly the given number of decimal places,
+// rounding with the given rounding mode (default "half-even") if necessary.
+//
+// Example 1:
+//
+//	{{ decimal.Format 5 2 }} // Output: 5.00
+//
+// Example 2:
+//
+//	{{ decimal.Format "1.005" 2 "half-up" }} // Output: 1.01
+func (ctx Decimal) Format(d any, decimals int, mode ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalFormat]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.DecimalFormat}
+	}
+	if fn, ok := override[func(any, int, ...string) (string, error)](ctx.options, funcs.DecimalFormat); ok {
+		return fn(d, decimals, mode...)
+	}
+	rm, err := toRoundingMode(mode)
+	if err != nil {
+		return "", err
+	}
+	v, err := toDecimal(d)
+	if err != nil {
+		return "", err
+	}
+	r, err := roundDecimal(v, decimals, rm)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// Sum returns the sum of the given values. The values can also be passed as a single slice.
+//
+// Example:
+//
+//	{{ decimal.Sum ( slice.New "19.99" "5.01" 0.1 ) }} // Output: 25.10
+func (ctx Decimal) Sum(values ...any) (DecimalValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalSum]; !ok {
+		return DecimalValue{}, &FuncNotAllowedError{Func: funcs.DecimalSum}
+	}
+	if fn, ok := override[func(...any) (DecimalValue, error)](ctx.options, funcs.DecimalSum); ok {
+		return fn(values...)
+	}
+	decimals, err := toDecimals(values)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	result := DecimalValue{unscaled: new(big.Int), scale: 0}
+	for _, d := range decimals {
+		scale := max(result.scale, d.scale)
+		result = DecimalValue{unscaled: new(big.Int).Add(result.rescale(scale), d.rescale(scale)), scale: scale}
+	}
+	return checkDigits(result)
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and +1 if a > b.
+//
+// Example:
+//
+//	{{ decimal.Cmp "1.50" 1.5 }} // Output: 0
+func (ctx Decimal) Cmp(a, b any) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalCmp]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.DecimalCmp}
+	}
+	if fn, ok := override[func(any, any) (int, error)](ctx.options, funcs.DecimalCmp); ok {
+		return fn(a, b)
+	}
+	x, err := toDecimal(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return 0, err
+	}
+	return x.Cmp(y), nil
+}
+
+// Equal reports whether a and b represent the same number, regardless of their scale.
+//
+// Example:
+//
+//	{{ decimal.Equal "1.50" "1.5" }} // Output: true
+func (ctx Decimal) Equal(a, b any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.DecimalEqual]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.DecimalEqual}
+	}
+	if fn, ok := override[func(any, any) (bool, error)](ctx.options, funcs.DecimalEqual); ok {
+		return fn(a, b)
+	}
+	x, err := toDecimal(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return false, err
+	}
+	return x.Cmp(y) == 0, nil
+}
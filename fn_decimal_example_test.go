@@ -0,0 +1,154 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleDecimal_Add() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Add 0.1 0.2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0.3
+}
+
+func ExampleDecimal_Cmp() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Cmp "1.50" 1.5 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0
+}
+
+func ExampleDecimal_Div() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Div 10 3 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3.33
+}
+
+func ExampleDecimal_Div_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Div 1 8 2 "half-up" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0.13
+}
+
+func ExampleDecimal_Div_third() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Div 1 8 2 "half-even" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0.12
+}
+
+func ExampleDecimal_Equal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Equal "1.50" "1.5" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleDecimal_Format() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Format 5 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 5.00
+}
+
+func ExampleDecimal_Format_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Format "1.005" 2 "half-up" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.01
+}
+
+func ExampleDecimal_Mul() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Mul "19.99" 3 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 59.97
+}
+
+func ExampleDecimal_New() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.New "12.50" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 12.50
+}
+
+func ExampleDecimal_New_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.New 0.1 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0.1
+}
+
+func ExampleDecimal_Round() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Round "2.345" 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2.34
+}
+
+func ExampleDecimal_Round_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Round "2.345" 2 "half-up" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2.35
+}
+
+func ExampleDecimal_Round_third() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Round "-2.349" 2 "down" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: -2.34
+}
+
+func ExampleDecimal_Sub() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Sub "10.00" "0.01" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 9.99
+}
+
+func ExampleDecimal_Sum() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ decimal.Sum ( slice.New "19.99" "5.01" 0.1 ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 25.10
+}
+
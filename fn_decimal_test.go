@@ -0,0 +1,186 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{
+			name: "json number",
+			tmpl: `{{ decimal.Add .price .tax }}`,
+			data: map[string]any{"price": 19.99, "tax": 3.8},
+			want: "23.79",
+		},
+		{
+			name: "exponent",
+			tmpl: `{{ decimal.New "1.5e3" }} {{ decimal.New "15e-4" }}`,
+			want: "1500 0.0015",
+		},
+		{
+			name: "large numbers",
+			tmpl: `{{ decimal.Mul "123456789012345678901234567890.5" 2 }}`,
+			want: "246913578024691357802469135781.0",
+		},
+		{
+			name: "negative half-even",
+			tmpl: `{{ decimal.Round "-2.5" 0 }} {{ decimal.Round "-3.5" 0 }}`,
+			want: "-2 -4",
+		},
+		{
+			name: "negative half-up",
+			tmpl: `{{ decimal.Round "-2.5" 0 "half-up" }}`,
+			want: "-3",
+		},
+		{
+			name: "negative division",
+			tmpl: `{{ decimal.Div -10 3 2 }} {{ decimal.Div 10 -3 2 "down" }}`,
+			want: "-3.33 -3.33",
+		},
+		{
+			name: "division scale smaller than operands",
+			tmpl: `{{ decimal.Div "1.2345" "0.5" 1 }}`,
+			want: "2.5",
+		},
+		{
+			name: "small value",
+			tmpl: `{{ decimal.Format "0.004" 2 "half-up" }} {{ decimal.Format "-0.004" 2 }}`,
+			want: "0.00 0.00",
+		},
+		{
+			name: "conv.ToString",
+			tmpl: `{{ conv.ToString ( decimal.New "-0.05" ) }}`,
+			want: "-0.05",
+		},
+		{
+			name: "json.Marshal",
+			tmpl: `{{ conv.ToString ( json.Marshal ( dict.New "total" ( decimal.New "10.50" ) ) ) }}`,
+			want: `{"total":10.50}`,
+		},
+		{
+			name: "compare",
+			tmpl: `{{ decimal.Cmp "0.1" "0.10001" }} {{ decimal.Cmp 2 "1.99" }}`,
+			want: "-1 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Safe)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr error
+	}{
+		{
+			name:    "invalid string",
+			tmpl:    `{{ decimal.New "1.2.3" }}`,
+			wantErr: xtemplate.ErrInvalidDecimal,
+		},
+		{
+			name:    "comma",
+			tmpl:    `{{ decimal.New "1,50" }}`,
+			wantErr: xtemplate.ErrInvalidDecimal,
+		},
+		{
+			name:    "thousands separator",
+			tmpl:    `{{ decimal.New "1,234.50" }}`,
+			wantErr: xtemplate.ErrInvalidDecimal,
+		},
+		{
+			name:    "invalid type",
+			tmpl:    `{{ decimal.New true }}`,
+			wantErr: xtemplate.ErrInvalidDecimal,
+		},
+		{
+			name:    "division by zero",
+			tmpl:    `{{ decimal.Div 1 "0.00" 2 }}`,
+			wantErr: xtemplate.ErrDivisionByZero,
+		},
+		{
+			name:    "negative scale",
+			tmpl:    `{{ decimal.Round 1 -1 }}`,
+			wantErr: xtemplate.ErrDecimalScaleOutOfRange,
+		},
+		{
+			name:    "scale too large",
+			tmpl:    `{{ decimal.Div 1 3 1000000 }}`,
+			wantErr: xtemplate.ErrDecimalScaleOutOfRange,
+		},
+		{
+			name:    "too many digits",
+			tmpl:    `{{ decimal.New "1e1000" }}`,
+			wantErr: xtemplate.ErrDecimalOutOfRange,
+		},
+		{
+			name:    "too many digits in string",
+			tmpl:    `{{ decimal.New ( strings.Repeat "9" 1001 ) }}`,
+			wantErr: xtemplate.ErrDecimalOutOfRange,
+		},
+		{
+			name:    "chained multiplication",
+			tmpl:    `{{ $y := decimal.New "1e100" }}{{ range 16 }}{{ $y = decimal.Mul $y $y }}{{ end }}`,
+			wantErr: xtemplate.ErrDecimalOutOfRange,
+		},
+		{
+			name:    "rescale",
+			tmpl:    `{{ decimal.Round ( strings.Repeat "9" 999 ) 2 }}`,
+			wantErr: xtemplate.ErrDecimalOutOfRange,
+		},
+		{
+			name:    "division result",
+			tmpl:    `{{ decimal.Div ( strings.Repeat "9" 999 ) "0.01" 0 }}`,
+			wantErr: xtemplate.ErrDecimalOutOfRange,
+		},
+		{
+			name:    "unknown rounding mode",
+			tmpl:    `{{ decimal.Round 1 0 "up" }}`,
+			wantErr: &xtemplate.UnknownRoundingModeError{Mode: "up"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Decimal, funcs.StringsRepeat)
+			var modeErr *xtemplate.UnknownRoundingModeError
+			if errors.As(tt.wantErr, &modeErr) {
+				var gotErr *xtemplate.UnknownRoundingModeError
+				if !errors.As(err, &gotErr) || *gotErr != *modeErr {
+					t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,6 +16,9 @@ func (ctx Dict) New(vals ...any) (map[any]any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.DictNew]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.DictNew}
 	}
+	if fn, ok := override[func(...any) (map[any]any, error)](ctx.options, funcs.DictNew); ok {
+		return fn(vals...)
+	}
 
 	result := make(map[any]any)
 	if len(vals)%2 != 0 {
@@ -42,6 +45,9 @@ func (ctx Dict) HasKey(m map[any]any, key any) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.DictHasKey]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.DictHasKey}
 	}
+	if fn, ok := override[func(map[any]any, any) (bool, error)](ctx.options, funcs.DictHasKey); ok {
+		return fn(m, key)
+	}
 	_, exists := m[key]
 	return exists, nil
 }
@@ -59,6 +65,9 @@ func (ctx Dict) HasValue(m map[any]any, value any) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.DictHasValue]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.DictHasValue}
 	}
+	if fn, ok := override[func(map[any]any, any) (bool, error)](ctx.options, funcs.DictHasValue); ok {
+		return fn(m, value)
+	}
 	for _, v := range m {
 		if v == value {
 			return true, nil
@@ -68,6 +77,7 @@ func (ctx Dict) HasValue(m map[any]any, value any) (bool, error) {
 }
 
 // Keys returns the keys of a map as a slice.
+// The order of the keys is unspecified, unless the deterministic mode is enabled.
 //
 // Example:
 //
@@ -78,10 +88,16 @@ func (ctx Dict) Keys(m map[any]any) ([]any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.DictKeys]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.DictKeys}
 	}
+	if fn, ok := override[func(map[any]any) ([]any, error)](ctx.options, funcs.DictKeys); ok {
+		return fn(m)
+	}
 	keys := make([]any, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
 	}
+	if ctx.options.deterministic {
+		sortAny(keys)
+	}
 	return keys, nil
 }
 
@@ -94,5 +110,8 @@ func (ctx Dict) IsEmpty(m map[any]any) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.DictIsEmpty]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.DictIsEmpty}
 	}
+	if fn, ok := override[func(map[any]any) (bool, error)](ctx.options, funcs.DictIsEmpty); ok {
+		return fn(m)
+	}
 	return len(m) == 0, nil
 }
@@ -0,0 +1,331 @@
+package xtemplate
+
+import (
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Encoding provides access to the encoders in the encoding/base64, encoding/base32, encoding/hex and
+// encoding/ascii85 packages.
+//
+// All functions accept strings and []byte values, e.g. the result of json.Marshal or os.ReadFile.
+// Decode functions return the decoded data as a string.
+type Encoding rootContext
+
+// ErrStringOrBytesRequired is returned when a function that expects a string or []byte receives another type.
+var ErrStringOrBytesRequired = errors.New("string or []byte required")
+
+// DecodeError is returned when the input of a decode function is malformed.
+type DecodeError struct {
+	Encoding string
+	Offset   int64
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("illegal %s data at input byte %d", e.Encoding, e.Offset)
+}
+
+func toBytes(v any) ([]byte, error) {
+	switch tv := v.(type) {
+	case string:
+		return []byte(tv), nil
+	case []byte:
+		return tv, nil
+	case fmt.Stringer:
+		return []byte(tv.String()), nil
+	default:
+		return nil, fmt.Errorf("%w, got %T", ErrStringOrBytesRequired, v)
+	}
+}
+
+func encodeWith(v any, enc func([]byte) string) (string, error) {
+	b, err := toBytes(v)
+	if err != nil {
+		return "", err
+	}
+	return enc(b), nil
+}
+
+func decodeBase64(v any, name string, enc *base64.Encoding) (string, error) {
+	b, err := toBytes(v)
+	if err != nil {
+		return "", err
+	}
+	out := make([]byte, enc.DecodedLen(len(b)))
+	n, err := enc.Decode(out, b)
+	if err != nil {
+		var corrupt base64.CorruptInputError
+		if errors.As(err, &corrupt) {
+			return "", &DecodeError{Encoding: name, Offset: int64(corrupt)}
+		}
+		return "", err
+	}
+	return string(out[:n]), nil
+}
+
+// Base64Encode returns the standard base64 encoding of v.
+//
+// Example:
+//
+//	{{ encoding.Base64Encode "Hello World" }} // Output: SGVsbG8gV29ybGQ=
+func (ctx Encoding) Base64Encode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64Encode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64Encode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64Encode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, base64.StdEncoding.EncodeToString)
+}
+
+// Base64Decode decodes the standard base64 encoded v.
+//
+// Example:
+//
+//	{{ encoding.Base64Decode "SGVsbG8gV29ybGQ=" }} // Output: Hello World
+func (ctx Encoding) Base64Decode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64Decode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64Decode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64Decode); ok {
+		return fn(v)
+	}
+	return decodeBase64(v, "base64", base64.StdEncoding)
+}
+
+// Base64URLEncode returns the URL-safe base64 encoding of v.
+//
+// Example:
+//
+//	{{ encoding.Base64URLEncode "a?b>c" }} // Output: YT9iPmM=
+func (ctx Encoding) Base64URLEncode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64URLEncode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64URLEncode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64URLEncode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, base64.URLEncoding.EncodeToString)
+}
+
+// Base64URLDecode decodes the URL-safe base64 encoded v.
+//
+// Example:
+//
+//	{{ encoding.Base64URLDecode "YT9iPmM=" }} // Output: a?b>c
+func (ctx Encoding) Base64URLDecode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64URLDecode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64URLDecode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64URLDecode); ok {
+		return fn(v)
+	}
+	return decodeBase64(v, "base64", base64.URLEncoding)
+}
+
+// Base64RawEncode returns the standard base64 encoding of v without padding.
+//
+// Example:
+//
+//	{{ encoding.Base64RawEncode "Hello World" }} // Output: SGVsbG8gV29ybGQ
+func (ctx Encoding) Base64RawEncode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64RawEncode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64RawEncode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64RawEncode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, base64.RawStdEncoding.EncodeToString)
+}
+
+// Base64RawDecode decodes the standard base64 encoded v without padding.
+//
+// Example:
+//
+//	{{ encoding.Base64RawDecode "SGVsbG8gV29ybGQ" }} // Output: Hello World
+func (ctx Encoding) Base64RawDecode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64RawDecode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64RawDecode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64RawDecode); ok {
+		return fn(v)
+	}
+	return decodeBase64(v, "base64", base64.RawStdEncoding)
+}
+
+// Base64RawURLEncode returns the URL-safe base64 encoding of v without padding.
+//
+// Example:
+//
+//	{{ encoding.Base64RawURLEncode "a?b>c" }} // Output: YT9iPmM
+func (ctx Encoding) Base64RawURLEncode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64RawURLEncode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64RawURLEncode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64RawURLEncode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, base64.RawURLEncoding.EncodeToString)
+}
+
+// Base64RawURLDecode decodes the URL-safe base64 encoded v without padding.
+//
+// Example:
+//
+//	{{ encoding.Base64RawURLDecode "YT9iPmM" }} // Output: a?b>c
+func (ctx Encoding) Base64RawURLDecode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase64RawURLDecode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase64RawURLDecode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase64RawURLDecode); ok {
+		return fn(v)
+	}
+	return decodeBase64(v, "base64", base64.RawURLEncoding)
+}
+
+// Base32Encode returns the standard base32 encoding of v.
+//
+// Example:
+//
+//	{{ encoding.Base32Encode "Hello" }} // Output: JBSWY3DP
+func (ctx Encoding) Base32Encode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase32Encode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase32Encode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase32Encode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, base32.StdEncoding.EncodeToString)
+}
+
+// Base32Decode decodes the standard base32 encoded v.
+//
+// Example:
+//
+//	{{ encoding.Base32Decode "JBSWY3DP" }} // Output: Hello
+func (ctx Encoding) Base32Decode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingBase32Decode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingBase32Decode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingBase32Decode); ok {
+		return fn(v)
+	}
+	b, err := toBytes(v)
+	if err != nil {
+		return "", err
+	}
+	out := make([]byte, base32.StdEncoding.DecodedLen(len(b)))
+	n, err := base32.StdEncoding.Decode(out, b)
+	if err != nil {
+		var corrupt base32.CorruptInputError
+		if errors.As(err, &corrupt) {
+			return "", &DecodeError{Encoding: "base32", Offset: int64(corrupt)}
+		}
+		return "", err
+	}
+	return string(out[:n]), nil
+}
+
+// HexEncode returns the hexadecimal encoding of v.
+//
+// Example:
+//
+//	{{ encoding.HexEncode "Hello" }} // Output: 48656c6c6f
+func (ctx Encoding) HexEncode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingHexEncode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingHexEncode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingHexEncode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, hex.EncodeToString)
+}
+
+// HexDecode decodes the hexadecimal encoded v.
+//
+// Example:
+//
+//	{{ encoding.HexDecode "48656c6c6f" }} // Output: Hello
+func (ctx Encoding) HexDecode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingHexDecode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingHexDecode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingHexDecode); ok {
+		return fn(v)
+	}
+	b, err := toBytes(v)
+	if err != nil {
+		return "", err
+	}
+	out := make([]byte, hex.DecodedLen(len(b)))
+	n, err := hex.Decode(out, b)
+	if err != nil {
+		// hex does not report offsets, find the first invalid byte after the successfully decoded ones
+		offset := int64(min(2*n, len(b)))
+		for i := 2 * n; i < len(b); i++ {
+			if !isHexDigit(b[i]) {
+				offset = int64(i)
+				break
+			}
+		}
+		return "", &DecodeError{Encoding: "hex", Offset: offset}
+	}
+	return string(out[:n]), nil
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// ASCII85Encode returns the ascii85 encoding of v.
+//
+// Example:
+//
+//	{{ encoding.ASCII85Encode "Hello" }} // Output: 87cURDZ
+func (ctx Encoding) ASCII85Encode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingASCII85Encode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingASCII85Encode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingASCII85Encode); ok {
+		return fn(v)
+	}
+	return encodeWith(v, func(b []byte) string {
+		out := make([]byte, ascii85.MaxEncodedLen(len(b)))
+		return string(out[:ascii85.Encode(out, b)])
+	})
+}
+
+// ASCII85Decode decodes the ascii85 encoded v.
+//
+// Example:
+//
+//	{{ encoding.ASCII85Decode "87cURDZ" }} // Output: Hello
+func (ctx Encoding) ASCII85Decode(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.EncodingASCII85Decode]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.EncodingASCII85Decode}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.EncodingASCII85Decode); ok {
+		return fn(v)
+	}
+	b, err := toBytes(v)
+	if err != nil {
+		return "", err
+	}
+	// each input byte decodes to at most 4 bytes ('z' is shorthand for 4 zero bytes)
+	out := make([]byte, 4*len(b)) //nolint:mnd // see above
+	n, _, err := ascii85.Decode(out, b, true)
+	if err != nil {
+		var corrupt ascii85.CorruptInputError
+		if errors.As(err, &corrupt) {
+			return "", &DecodeError{Encoding: "ascii85", Offset: int64(corrupt)}
+		}
+		return "", err
+	}
+	return string(out[:n]), nil
+}
@@ -0,0 +1,136 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleEncoding_ASCII85Decode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.ASCII85Decode "87cURDZ" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello
+}
+
+func ExampleEncoding_ASCII85Encode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.ASCII85Encode "Hello" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 87cURDZ
+}
+
+func ExampleEncoding_Base32Decode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base32Decode "JBSWY3DP" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello
+}
+
+func ExampleEncoding_Base32Encode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base32Encode "Hello" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: JBSWY3DP
+}
+
+func ExampleEncoding_Base64Decode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64Decode "SGVsbG8gV29ybGQ=" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello World
+}
+
+func ExampleEncoding_Base64Encode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64Encode "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: SGVsbG8gV29ybGQ=
+}
+
+func ExampleEncoding_Base64RawDecode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64RawDecode "SGVsbG8gV29ybGQ" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello World
+}
+
+func ExampleEncoding_Base64RawEncode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64RawEncode "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: SGVsbG8gV29ybGQ
+}
+
+func ExampleEncoding_Base64RawURLDecode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64RawURLDecode "YT9iPmM" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a?b>c
+}
+
+func ExampleEncoding_Base64RawURLEncode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64RawURLEncode "a?b>c" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: YT9iPmM
+}
+
+func ExampleEncoding_Base64URLDecode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64URLDecode "YT9iPmM=" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a?b>c
+}
+
+func ExampleEncoding_Base64URLEncode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.Base64URLEncode "a?b>c" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: YT9iPmM=
+}
+
+func ExampleEncoding_HexDecode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.HexDecode "48656c6c6f" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello
+}
+
+func ExampleEncoding_HexEncode() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ encoding.HexEncode "Hello" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 48656c6c6f
+}
+
@@ -0,0 +1,116 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "bytes",
+			tmpl: `{{ encoding.Base64Encode ( json.Marshal ( dict.New "a" 1 ) ) }}`,
+			want: "eyJhIjoxfQ==",
+		},
+		{
+			name: "round trip binary",
+			tmpl: `{{ encoding.HexEncode ( encoding.Base64Decode "AP8Q" ) }}`,
+			want: "00ff10",
+		},
+		{
+			name: "base64 with newlines",
+			tmpl: "{{ encoding.Base64Decode \"SGVs\\nbG8=\" }}",
+			want: "Hello",
+		},
+		{
+			name: "ascii85 zero group",
+			tmpl: `{{ encoding.HexEncode ( encoding.ASCII85Decode "z" ) }}`,
+			want: "00000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Safe)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoding_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr *xtemplate.DecodeError
+	}{
+		{
+			name:    "base64",
+			tmpl:    `{{ encoding.Base64Decode "SGVs*G8=" }}`,
+			wantErr: &xtemplate.DecodeError{Encoding: "base64", Offset: 4},
+		},
+		{
+			name:    "base64 url",
+			tmpl:    `{{ encoding.Base64URLDecode "YT9iPmM+" }}`,
+			wantErr: &xtemplate.DecodeError{Encoding: "base64", Offset: 7},
+		},
+		{
+			name:    "base32",
+			tmpl:    `{{ encoding.Base32Decode "JBSWY3D1" }}`,
+			wantErr: &xtemplate.DecodeError{Encoding: "base32", Offset: 7},
+		},
+		{
+			name:    "hex invalid byte",
+			tmpl:    `{{ encoding.HexDecode "4865zc" }}`,
+			wantErr: &xtemplate.DecodeError{Encoding: "hex", Offset: 4},
+		},
+		{
+			name:    "hex odd length",
+			tmpl:    `{{ encoding.HexDecode "48656" }}`,
+			wantErr: &xtemplate.DecodeError{Encoding: "hex", Offset: 4},
+		},
+		{
+			name:    "ascii85",
+			tmpl:    `{{ encoding.ASCII85Decode "87c~RDZ" }}`,
+			wantErr: &xtemplate.DecodeError{Encoding: "ascii85", Offset: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Encoding)
+			var gotErr *xtemplate.DecodeError
+			if !errors.As(err, &gotErr) || *gotErr != *tt.wantErr {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("invalid type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xtemplate.QuickExecute(`{{ encoding.HexEncode 1 }}`, nil, funcs.Encoding)
+		if !errors.Is(err, xtemplate.ErrStringOrBytesRequired) {
+			t.Errorf("QuickExecute() error = %v, want %v", err, xtemplate.ErrStringOrBytesRequired)
+		}
+	})
+}
@@ -1,11 +1,16 @@
 package xtemplate
 
 import (
+	"errors"
+	"io/fs"
 	"path/filepath"
 
 	"github.com/Eun/xtemplate/funcs"
 )
 
+// ErrNoFS is returned by functions that access files when no file system was configured with WithFS.
+var ErrNoFS = errors.New("no file system configured")
+
 // FilePath provides access to functions in the path/filepath package.
 type FilePath rootContext
 
@@ -19,6 +24,9 @@ func (ctx FilePath) Dir(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathDir]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathDir}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathDir); ok {
+		return fn(s)
+	}
 	return filepath.Dir(s), nil
 }
 
@@ -32,6 +40,9 @@ func (ctx FilePath) Base(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathBase]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathBase}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathBase); ok {
+		return fn(s)
+	}
 	return filepath.Base(s), nil
 }
 
@@ -45,6 +56,9 @@ func (ctx FilePath) Join(s ...string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathJoin]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathJoin}
 	}
+	if fn, ok := override[func(...string) (string, error)](ctx.options, funcs.FilePathJoin); ok {
+		return fn(s...)
+	}
 	return filepath.Join(s...), nil
 }
 
@@ -58,6 +72,9 @@ func (ctx FilePath) Clean(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathClean]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathClean}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathClean); ok {
+		return fn(s)
+	}
 	return filepath.Clean(s), nil
 }
 
@@ -71,6 +88,9 @@ func (ctx FilePath) Ext(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathExt]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathExt}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathExt); ok {
+		return fn(s)
+	}
 	return filepath.Ext(s), nil
 }
 
@@ -84,6 +104,9 @@ func (ctx FilePath) Abs(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathAbs]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathAbs}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathAbs); ok {
+		return fn(s)
+	}
 	return filepath.Abs(s)
 }
 
@@ -97,6 +120,9 @@ func (ctx FilePath) Rel(basepath, targetpath string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathRel]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathRel}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.FilePathRel); ok {
+		return fn(basepath, targetpath)
+	}
 	return filepath.Rel(basepath, targetpath)
 }
 
@@ -110,6 +136,9 @@ func (ctx FilePath) FromSlash(path string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathFromSlash]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathFromSlash}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathFromSlash); ok {
+		return fn(path)
+	}
 	return filepath.FromSlash(path), nil
 }
 
@@ -123,5 +152,148 @@ func (ctx FilePath) ToSlash(path string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.FilePathToSlash]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.FilePathToSlash}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathToSlash); ok {
+		return fn(path)
+	}
 	return filepath.ToSlash(path), nil
 }
+
+// Match reports whether name matches the shell pattern.
+// The pattern syntax is the one of filepath.Match, e.g. "*" matches any sequence of non-separator characters.
+//
+// Example:
+//
+//	{{ filepath.Match "*.go" "main.go" }}
+func (ctx FilePath) Match(pattern, name string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathMatch]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.FilePathMatch}
+	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.FilePathMatch); ok {
+		return fn(pattern, name)
+	}
+	return filepath.Match(pattern, name)
+}
+
+// Glob returns the names of all files in the file system configured with WithFS that match the pattern.
+// The pattern syntax is the one of Match, names are slash separated and relative to the root of the file system.
+// Glob fails with ErrNoFS if no file system was configured, it never accesses the host file system.
+//
+// Example:
+//
+//	{{ filepath.Glob "templates/*.tmpl" }}
+func (ctx FilePath) Glob(pattern string) ([]string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathGlob]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.FilePathGlob}
+	}
+	if fn, ok := override[func(string) ([]string, error)](ctx.options, funcs.FilePathGlob); ok {
+		return fn(pattern)
+	}
+	if ctx.options.fsys == nil {
+		return nil, ErrNoFS
+	}
+	matches, err := fs.Glob(ctx.options.fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if matches == nil {
+		return []string{}, nil
+	}
+	return matches, nil
+}
+
+// Split splits path immediately following the final separator, separating it into a directory and file name
+// component. If there is no separator in path, Split returns an empty dir and file set to path.
+//
+// Example:
+//
+//	{{ (filepath.Split "static/css/site.css").File }}
+func (ctx FilePath) Split(s string) (PathSplitResult, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathSplit]; !ok {
+		return PathSplitResult{}, &FuncNotAllowedError{Func: funcs.FilePathSplit}
+	}
+	if fn, ok := override[func(string) (PathSplitResult, error)](ctx.options, funcs.FilePathSplit); ok {
+		return fn(s)
+	}
+	dir, file := filepath.Split(s)
+	return PathSplitResult{Dir: dir, File: file}, nil
+}
+
+// SplitList splits a list of paths joined by the OS specific ListSeparator, usually found in PATH or GOPATH
+// environment variables. It returns an empty list for an empty string.
+//
+// Example:
+//
+//	{{ filepath.SplitList "/a/bin:/b/bin" }}
+func (ctx FilePath) SplitList(s string) ([]string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathSplitList]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.FilePathSplitList}
+	}
+	if fn, ok := override[func(string) ([]string, error)](ctx.options, funcs.FilePathSplitList); ok {
+		return fn(s)
+	}
+	return filepath.SplitList(s), nil
+}
+
+// IsAbs reports whether the path is absolute.
+//
+// Example:
+//
+//	{{ filepath.IsAbs "/etc/hosts" }}
+func (ctx FilePath) IsAbs(s string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathIsAbs]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.FilePathIsAbs}
+	}
+	if fn, ok := override[func(string) (bool, error)](ctx.options, funcs.FilePathIsAbs); ok {
+		return fn(s)
+	}
+	return filepath.IsAbs(s), nil
+}
+
+// IsLocal reports whether path is local: it is within the subtree rooted at the directory in which path is
+// evaluated, is not absolute, is not empty and on Windows is not a reserved name.
+// IsLocal is a lexical check, it does not resolve symbolic links.
+//
+// Example:
+//
+//	{{ filepath.IsLocal "a/../../b" }}
+func (ctx FilePath) IsLocal(s string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathIsLocal]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.FilePathIsLocal}
+	}
+	if fn, ok := override[func(string) (bool, error)](ctx.options, funcs.FilePathIsLocal); ok {
+		return fn(s)
+	}
+	return filepath.IsLocal(s), nil
+}
+
+// VolumeName returns the leading volume name, e.g. "C:" for "C:\foo\bar" on Windows.
+// On other platforms it returns an empty string.
+//
+// Example:
+//
+//	{{ filepath.VolumeName "C:\\foo\\bar" }}
+func (ctx FilePath) VolumeName(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathVolumeName]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.FilePathVolumeName}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathVolumeName); ok {
+		return fn(s)
+	}
+	return filepath.VolumeName(s), nil
+}
+
+// Localize converts a slash separated path into an operating system path.
+// The path must be a valid path as reported by io/fs.ValidPath, otherwise Localize returns an error.
+//
+// Example:
+//
+//	{{ filepath.Localize "static/css/site.css" }}
+func (ctx FilePath) Localize(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FilePathLocalize]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.FilePathLocalize}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.FilePathLocalize); ok {
+		return fn(s)
+	}
+	return filepath.Localize(s)
+}
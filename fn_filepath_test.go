@@ -0,0 +1,111 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func mapFile(data string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(data), Mode: 0, ModTime: time.Time{}, Sys: nil}
+}
+
+func TestFilePath_Glob(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"templates/a.tmpl":       mapFile("a"),
+		"templates/b.tmpl":       mapFile("b"),
+		"templates/readme.md":    mapFile("c"),
+		"templates/sub/c.tmpl":   mapFile("d"),
+		"templates/sub/d.tmpl.x": mapFile("e"),
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "directory",
+			tmpl: `{{ filepath.Glob "templates/*.tmpl" }}`,
+			want: "[templates/a.tmpl templates/b.tmpl]",
+		},
+		{name: "nested", tmpl: `{{ filepath.Glob "*/*/*.tmpl" }}`, want: "[templates/sub/c.tmpl]"},
+		{name: "no match", tmpl: `{{ len ( filepath.Glob "*.go" ) }}`, want: "0"},
+		{
+			name: "range",
+			tmpl: `{{ range filepath.Glob "templates/[ab].tmpl" }}{{ filepath.Base . }};{{ end }}`,
+			want: "a.tmpl;b.tmpl;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.FilePath, xtemplate.WithFS(fsys))
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilePath_GlobErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := xtemplate.QuickExecute(`{{ filepath.Glob "*" }}`, nil, funcs.FilePath)
+	if !errors.Is(err, xtemplate.ErrNoFS) {
+		t.Errorf("Glob() error = %v, want %v", err, xtemplate.ErrNoFS)
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ filepath.Glob "[" }}`, nil, funcs.FilePath, xtemplate.WithFS(fstest.MapFS{}))
+	if !errors.Is(err, path.ErrBadPattern) {
+		t.Errorf("Glob() error = %v, want %v", err, path.ErrBadPattern)
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{name: "match", tmpl: `{{ filepath.Match "*.go" "main.go" }}`, want: "true"},
+		{name: "match separator", tmpl: `{{ filepath.Match "*.go" "cmd/main.go" }}`, want: "false"},
+		{name: "split", tmpl: `{{ $s := filepath.Split "a/b/c.txt" }}{{ $s.Dir }} {{ $s.File }}`, want: "a/b/ c.txt"},
+		{name: "is local", tmpl: `{{ filepath.IsLocal "a/b" }} {{ filepath.IsLocal "a/../../b" }}`, want: "true false"},
+		{name: "split list empty", tmpl: `{{ len ( filepath.SplitList "" ) }}`, want: "0"},
+		{name: "localize", tmpl: `{{ filepath.Localize "a/b" | filepath.ToSlash }}`, want: "a/b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.FilePath)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	_, err := xtemplate.QuickExecute(`{{ filepath.Localize "../a" }}`, nil, funcs.FilePath)
+	if err == nil {
+		t.Error("Localize() expected an error for a non local path")
+	}
+}
@@ -0,0 +1,356 @@
+package xtemplate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Fmt provides formatting functions like the builtin printf, but restricted by the allowlist and the FmtPolicy
+// set with WithFmtPolicy.
+//
+// Unlike printf the functions never call methods of foreign arguments (e.g. String, Error or Format):
+// values are formatted by their underlying data, structs by their exported fields, and pointers by the value
+// they point to. Only the values of this package (e.g. DecimalValue, SemverValue and URLValue) and the
+// time.Time, time.Duration, netip.Addr and netip.Prefix values are formatted by their own methods.
+type Fmt rootContext
+
+// ErrFormatNotAllowed is returned when a format string violates the FmtPolicy.
+var ErrFormatNotAllowed = errors.New("format is not allowed")
+
+// maxFmtDepth limits the nesting of formatted values, it also stops cyclic values.
+const maxFmtDepth = 100
+
+//nolint:gochecknoglobals // lookup table
+var fmtBasicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeFor[bool](),
+	reflect.Int:        reflect.TypeFor[int](),
+	reflect.Int8:       reflect.TypeFor[int8](),
+	reflect.Int16:      reflect.TypeFor[int16](),
+	reflect.Int32:      reflect.TypeFor[int32](),
+	reflect.Int64:      reflect.TypeFor[int64](),
+	reflect.Uint:       reflect.TypeFor[uint](),
+	reflect.Uint8:      reflect.TypeFor[uint8](),
+	reflect.Uint16:     reflect.TypeFor[uint16](),
+	reflect.Uint32:     reflect.TypeFor[uint32](),
+	reflect.Uint64:     reflect.TypeFor[uint64](),
+	reflect.Uintptr:    reflect.TypeFor[uintptr](),
+	reflect.Float32:    reflect.TypeFor[float32](),
+	reflect.Float64:    reflect.TypeFor[float64](),
+	reflect.Complex64:  reflect.TypeFor[complex64](),
+	reflect.Complex128: reflect.TypeFor[complex128](),
+	reflect.String:     reflect.TypeFor[string](),
+}
+
+// fmtTrustedTypes are formatted by their own methods, they are known to not run foreign code.
+//
+//nolint:gochecknoglobals // lookup table
+var fmtTrustedTypes = map[reflect.Type]struct{}{
+	reflect.TypeFor[DecimalValue]():      {},
+	reflect.TypeFor[SemverValue]():       {},
+	reflect.TypeFor[URLValue]():          {},
+	reflect.TypeFor[time.Time]():         {},
+	reflect.TypeFor[time.Duration]():     {},
+	reflect.TypeFor[TOMLLocalDate]():     {},
+	reflect.TypeFor[TOMLLocalTime]():     {},
+	reflect.TypeFor[TOMLLocalDateTime](): {},
+	reflect.TypeFor[netip.Addr]():        {},
+	reflect.TypeFor[netip.Prefix]():      {},
+}
+
+// plainValues returns copies of args without methods, so formatting them cannot run foreign code.
+func plainValues(args []any) ([]any, error) {
+	result := make([]any, len(args))
+	for i, arg := range args {
+		v, err := plainValue(reflect.ValueOf(arg), 0)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+//nolint:cyclop, funlen // cannot be simplified
+func plainValue(v reflect.Value, depth int) (any, error) {
+	if depth > maxFmtDepth {
+		return nil, fmt.Errorf("%w: value is nested deeper than %d levels", ErrInvalidArgument, maxFmtDepth)
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if _, ok := fmtTrustedTypes[v.Type()]; ok {
+		return v.Interface(), nil
+	}
+	if t, ok := fmtBasicTypes[v.Kind()]; ok {
+		return v.Convert(t).Interface(), nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return plainValue(v.Elem(), depth+1)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			for i := range b {
+				b[i] = byte(v.Index(i).Uint())
+			}
+			return b, nil
+		}
+		s := make([]any, v.Len())
+		for i := range s {
+			e, err := plainValue(v.Index(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = e
+		}
+		return s, nil
+	case reflect.Map:
+		m := make(map[any]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := plainValue(iter.Key(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if k != nil && !isPlainMapKey(reflect.TypeOf(k)) {
+				k = fmt.Sprint(k)
+			}
+			e, err := plainValue(iter.Value(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = e
+		}
+		return m, nil
+	case reflect.Struct:
+		t := v.Type()
+		var fields []reflect.StructField
+		var values []any
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			e, err := plainValue(v.Field(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, reflect.StructField{
+				Name:      f.Name,
+				PkgPath:   "",
+				Type:      reflect.TypeFor[any](),
+				Tag:       "",
+				Offset:    0,
+				Index:     nil,
+				Anonymous: false,
+			})
+			values = append(values, e)
+		}
+		s := reflect.New(reflect.StructOf(fields)).Elem()
+		for i, e := range values {
+			if e != nil {
+				s.Field(i).Set(reflect.ValueOf(e))
+			}
+		}
+		return s.Interface(), nil
+	default:
+		// functions, channels and unsafe pointers
+		return "<" + v.Type().String() + ">", nil
+	}
+}
+
+// isPlainMapKey reports whether a plain value of type t can be used as a map key.
+// Composite values, e.g. structs or arrays, can hold values that are not hashable, such as []byte.
+func isPlainMapKey(t reflect.Type) bool {
+	if _, ok := fmtTrustedTypes[t]; ok {
+		return true
+	}
+	_, ok := fmtBasicTypes[t.Kind()]
+	return ok
+}
+
+// checkFormat reports whether format and the widths and precisions taken from args comply with policy.
+//
+//nolint:cyclop // cannot be simplified
+func checkFormat(policy FmtPolicy, format string, args []any) error {
+	argNum := 0
+	for i := 0; i < len(format); {
+		if format[i] != '%' {
+			i++
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		argNum, i = fmtArgIndex(format, i, argNum)
+		if i < len(format) && format[i] == '*' {
+			if err := checkFmtLimit("width", fmtStarArg(args, argNum), policy.MaxWidth); err != nil {
+				return err
+			}
+			argNum++
+			i++
+		} else {
+			var n int
+			n, i = fmtNumber(format, i)
+			if err := checkFmtLimit("width", n, policy.MaxWidth); err != nil {
+				return err
+			}
+		}
+		if i < len(format) && format[i] == '.' {
+			argNum, i = fmtArgIndex(format, i+1, argNum)
+			if i < len(format) && format[i] == '*' {
+				if err := checkFmtLimit("precision", fmtStarArg(args, argNum), policy.MaxPrecision); err != nil {
+					return err
+				}
+				argNum++
+				i++
+			} else {
+				var n int
+				n, i = fmtNumber(format, i)
+				if err := checkFmtLimit("precision", n, policy.MaxPrecision); err != nil {
+					return err
+				}
+			}
+		}
+		argNum, i = fmtArgIndex(format, i, argNum)
+		if i >= len(format) {
+			break
+		}
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		i += size
+		if verb == '%' {
+			continue
+		}
+		if policy.Verbs != "" && !strings.ContainsRune(policy.Verbs, verb) {
+			return fmt.Errorf("%w: verb %%%c", ErrFormatNotAllowed, verb)
+		}
+		argNum++
+	}
+	return nil
+}
+
+func checkFmtLimit(name string, n, limit int) error {
+	if n > limit {
+		return fmt.Errorf("%w: %s %d exceeds %d", ErrFormatNotAllowed, name, n, limit)
+	}
+	return nil
+}
+
+// fmtArgIndex parses an explicit argument index like [2] at format[i:].
+func fmtArgIndex(format string, i, argNum int) (int, int) {
+	if i >= len(format) || format[i] != '[' {
+		return argNum, i
+	}
+	end := strings.IndexByte(format[i:], ']')
+	if end < 0 {
+		return argNum, i
+	}
+	n, j := fmtNumber(format, i+1)
+	if j != i+end || n < 1 {
+		return argNum, i + end + 1
+	}
+	return n - 1, i + end + 1
+}
+
+// fmtNumber parses the decimal number at format[i:], large numbers saturate.
+func fmtNumber(format string, i int) (int, int) {
+	n := 0
+	for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+		n = min(n*10+int(format[i]-'0'), maxTextWidth) //nolint:mnd // decimal
+	}
+	return n, i
+}
+
+// fmtStarArg returns the absolute value of the integer argument that a * refers to, fmt rejects other types.
+func fmtStarArg(args []any, argNum int) int {
+	if argNum >= len(args) {
+		return 0
+	}
+	v := reflect.ValueOf(args[argNum])
+	//nolint:exhaustive // other kinds are not used as width or precision
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if n < 0 {
+			n = -n
+		}
+		return int(min(uint64(n), maxTextWidth)) //nolint:gosec // n is not negative
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(min(v.Uint(), maxTextWidth)) //nolint:gosec // limited to maxTextWidth
+	default:
+		return 0
+	}
+}
+
+// Sprintf formats args according to format, see fmt.Sprintf.
+//
+// Example:
+//
+//	{{ fmt.Sprintf "%05.1f|%-4s|%q" 3.14159 "ab" "x" }} // Output: 003.1|ab  |"x"
+func (ctx Fmt) Sprintf(format string, args ...any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FmtSprintf]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.FmtSprintf}
+	}
+	if fn, ok := override[func(string, ...any) (string, error)](ctx.options, funcs.FmtSprintf); ok {
+		return fn(format, args...)
+	}
+	if err := checkFormat(ctx.options.fmtPolicy, format, args); err != nil {
+		return "", err
+	}
+	values, err := plainValues(args)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(format, values...), nil
+}
+
+// Sprint formats args using their default formats, see fmt.Sprint.
+// Spaces are added between operands when neither is a string.
+//
+// Example:
+//
+//	{{ fmt.Sprint "a" 1 2 "b" }} // Output: a1 2b
+func (ctx Fmt) Sprint(args ...any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FmtSprint]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.FmtSprint}
+	}
+	if fn, ok := override[func(...any) (string, error)](ctx.options, funcs.FmtSprint); ok {
+		return fn(args...)
+	}
+	values, err := plainValues(args)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(values...), nil
+}
+
+// Sprintln formats args using their default formats, see fmt.Sprintln.
+// Spaces are always added between operands and a newline is appended.
+//
+// Example:
+//
+//	{{ fmt.Sprintln "a" 1 | printf "%q" }} // Output: "a 1\n"
+func (ctx Fmt) Sprintln(args ...any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.FmtSprintln]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.FmtSprintln}
+	}
+	if fn, ok := override[func(...any) (string, error)](ctx.options, funcs.FmtSprintln); ok {
+		return fn(args...)
+	}
+	values, err := plainValues(args)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintln(values...), nil
+}
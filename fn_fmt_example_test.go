@@ -0,0 +1,37 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleFmt_Sprint() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ fmt.Sprint "a" 1 2 "b" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a1 2b
+}
+
+func ExampleFmt_Sprintf() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ fmt.Sprintf "%05.1f|%-4s|%q" 3.14159 "ab" "x" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 003.1|ab  |"x"
+}
+
+func ExampleFmt_Sprintln() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ fmt.Sprintln "a" 1 | printf "%q" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "a 1\n"
+}
+
@@ -0,0 +1,205 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+type fmtSecret string
+
+func (fmtSecret) String() string { panic("String must not be called") }
+
+type fmtFailure struct {
+	Code   int
+	Reason fmtSecret
+	hidden string
+}
+
+func (*fmtFailure) Error() string { panic("Error must not be called") }
+
+type fmtNode struct {
+	Name string
+	Next *fmtNode
+}
+
+func TestFmt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{name: "sprintf", tmpl: `{{ fmt.Sprintf "%s=%03d" "a" 7 }}`, data: nil, want: "a=007"},
+		{name: "stringer", tmpl: `{{ fmt.Sprintf "%v %q" . . }}`, data: fmtSecret("x"), want: `x "x"`},
+		{
+			name: "error struct",
+			tmpl: `{{ fmt.Sprintf "%v %+v" . . }}`,
+			data: &fmtFailure{Code: 1, Reason: "r", hidden: "h"},
+			want: "{1 r} {Code:1 Reason:r}",
+		},
+		{
+			name: "nested values",
+			tmpl: `{{ fmt.Sprint . }}`,
+			data: map[fmtSecret][]fmtSecret{"k": {"a", "b"}},
+			want: "map[k:[a b]]",
+		},
+		{
+			name: "struct key with byte array",
+			tmpl: `{{ fmt.Sprint . }}`,
+			data: map[struct{ A [2]byte }]int{{A: [2]byte{1, 2}}: 3},
+			want: "map[{[1 2]}:3]",
+		},
+		{
+			name: "array key",
+			tmpl: `{{ fmt.Sprint . }}`,
+			data: map[[2]fmtSecret]int{{"a", "b"}: 1},
+			want: "map[[a b]:1]",
+		},
+		{name: "bytes", tmpl: `{{ fmt.Sprintf "%x" . }}`, data: []byte("hi"), want: "6869"},
+		{name: "float32", tmpl: `{{ fmt.Sprint . }}`, data: float32(0.1), want: "0.1"},
+		{name: "nil pointer", tmpl: `{{ fmt.Sprint . }}`, data: (*fmtNode)(nil), want: "<nil>"},
+		{name: "star width", tmpl: `{{ fmt.Sprintf "%*d|%-*d|" 4 1 3 2 }}`, data: nil, want: "   1|2  |"},
+		{name: "explicit index", tmpl: `{{ fmt.Sprintf "%[2]s %[1]s" "a" "b" }}`, data: nil, want: "b a"},
+		{name: "percent", tmpl: `{{ fmt.Sprintf "100%%" }}`, data: nil, want: "100%"},
+		{name: "sprint spaces", tmpl: `{{ fmt.Sprint 1 2 "a" 3 }}`, data: nil, want: "1 2a3"},
+		{name: "sprintln", tmpl: `{{ fmt.Sprintln "a" 1 }}`, data: nil, want: "a 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Fmt)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFmt_Cycle(t *testing.T) {
+	t.Parallel()
+
+	node := &fmtNode{Name: "a", Next: nil}
+	node.Next = node
+	_, err := xtemplate.QuickExecute(`{{ fmt.Sprint . }}`, node, funcs.Fmt)
+	if !errors.Is(err, xtemplate.ErrInvalidArgument) {
+		t.Errorf("QuickExecute() error = %v, want %v", err, xtemplate.ErrInvalidArgument)
+	}
+}
+
+func TestFmt_Policy(t *testing.T) {
+	t.Parallel()
+
+	policy := xtemplate.WithFmtPolicy(xtemplate.FmtPolicy{Verbs: "sdv", MaxWidth: 10, MaxPrecision: 0})
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		policy  bool
+		wantErr error
+	}{
+		{
+			name:    "default width",
+			tmpl:    `{{ fmt.Sprintf "%999999999d" 1 }}`,
+			policy:  false,
+			wantErr: xtemplate.ErrFormatNotAllowed,
+		},
+		{
+			name:    "default precision",
+			tmpl:    `{{ fmt.Sprintf "%.5000f" 1.0 }}`,
+			policy:  false,
+			wantErr: xtemplate.ErrFormatNotAllowed,
+		},
+		{
+			name:    "default star",
+			tmpl:    `{{ fmt.Sprintf "%*d" -5000 1 }}`,
+			policy:  false,
+			wantErr: xtemplate.ErrFormatNotAllowed,
+		},
+		{name: "default allows verbs", tmpl: `{{ fmt.Sprintf "%x %8.3f" 255 1.0 }}`, policy: false, wantErr: nil},
+		{name: "allowed", tmpl: `{{ fmt.Sprintf "%10s%d%v%%" "a" 1 2 }}`, policy: true, wantErr: nil},
+		{name: "verb", tmpl: `{{ fmt.Sprintf "%s %x" "a" 1 }}`, policy: true, wantErr: xtemplate.ErrFormatNotAllowed},
+		{name: "width", tmpl: `{{ fmt.Sprintf "%11d" 1 }}`, policy: true, wantErr: xtemplate.ErrFormatNotAllowed},
+		{name: "precision", tmpl: `{{ fmt.Sprintf "%.1v" 1.0 }}`, policy: true, wantErr: xtemplate.ErrFormatNotAllowed},
+		{
+			name:    "star precision with index",
+			tmpl:    `{{ fmt.Sprintf "%[2]s %.[1]*[2]v" 1 "ab" }}`,
+			policy:  true,
+			wantErr: xtemplate.ErrFormatNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			allowed := []xtemplate.AllowedFunctions{funcs.Fmt}
+			if tt.policy {
+				allowed = append(allowed, policy)
+			}
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, allowed...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFmt_Values(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "decimal",
+			tmpl: `{{ fmt.Sprintf "%s|%v" (decimal.New "1.50") (decimal.New "-0.25") }}`,
+			want: "1.50|-0.25",
+		},
+		{name: "semver", tmpl: `{{ fmt.Sprintf "v%s" (semver.Parse "v1.2.3-rc.1") }}`, want: "v1.2.3-rc.1"},
+		{
+			name: "url",
+			tmpl: `{{ fmt.Sprint (url.Parse "https://example.com/a?b=c") }}`,
+			want: "https://example.com/a?b=c",
+		},
+		{
+			name: "time",
+			tmpl: `{{ fmt.Sprintf "%s" (time.Parse "2006-01-02" "2024-03-01") }}`,
+			want: "2024-03-01 00:00:00 +0000 UTC",
+		},
+		{name: "duration", tmpl: `{{ fmt.Sprintf "%v" (time.ParseDuration "90m") }}`, want: "1h30m0s"},
+		{name: "addr", tmpl: `{{ fmt.Sprintf "%s" (net.ParseAddr "2001:db8::1") }}`, want: "2001:db8::1"},
+		{name: "prefix", tmpl: `{{ fmt.Sprintf "%v" (net.ParsePrefix "10.0.0.0/8") }}`, want: "10.0.0.0/8"},
+		{
+			name: "nested",
+			tmpl: `{{ fmt.Sprint (slice.New (semver.Parse "1.0.0") (decimal.New "2.5")) }}`,
+			want: "[1.0.0 2.5]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil,
+				funcs.Fmt, funcs.Decimal, funcs.Semver, funcs.URL, funcs.Time, funcs.Net, funcs.Slice)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
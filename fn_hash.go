@@ -0,0 +1,186 @@
+package xtemplate
+
+import (
+	"crypto/hmac"
+	"crypto/md5"  //nolint:gosec // provided for checksums, can be disallowed
+	"crypto/sha1" //nolint:gosec // provided for checksums, can be disallowed
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Hash provides checksums and message authentication codes.
+//
+// All functions accept strings and []byte values and return the digest hex encoded.
+// Pass "base64" as the last argument to get a standard base64 encoded digest instead.
+//
+// Every algorithm is a separate function, so weak algorithms like MD5 and SHA1 can be excluded from the
+// allowed functions.
+type Hash rootContext
+
+// UnknownDigestFormatError is returned when an unknown digest format is requested.
+type UnknownDigestFormatError struct {
+	Format string
+}
+
+func (e *UnknownDigestFormatError) Error() string {
+	return fmt.Sprintf("unknown digest format %q, use hex or base64", e.Format)
+}
+
+func sum(h hash.Hash, v any, format []string) (string, error) {
+	if len(format) > 1 {
+		return "", OnlyOneArgumentIsAllowedError{}
+	}
+	b, err := toBytes(v)
+	if err != nil {
+		return "", err
+	}
+	h.Write(b)
+	digest := h.Sum(nil)
+	if len(format) == 0 {
+		return hex.EncodeToString(digest), nil
+	}
+	switch format[0] {
+	case "hex":
+		return hex.EncodeToString(digest), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(digest), nil
+	default:
+		return "", &UnknownDigestFormatError{Format: format[0]}
+	}
+}
+
+// MD5 returns the MD5 checksum of v.
+//
+// Example:
+//
+//	{{ hash.MD5 "Hello World" }} // Output: b10a8db164e0754105b7a99be72e3fe5
+func (ctx Hash) MD5(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashMD5]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashMD5}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashMD5); ok {
+		return fn(v, format...)
+	}
+	return sum(md5.New(), v, format) //nolint:gosec // see import
+}
+
+// SHA1 returns the SHA-1 checksum of v.
+//
+// Example:
+//
+//	{{ hash.SHA1 "Hello World" }} // Output: 0a4d55a8d778e5022fab701977c5d840bbc486d0
+func (ctx Hash) SHA1(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashSHA1]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashSHA1}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashSHA1); ok {
+		return fn(v, format...)
+	}
+	return sum(sha1.New(), v, format) //nolint:gosec // see import
+}
+
+// SHA256 returns the SHA-256 checksum of v.
+//
+// Example 1:
+//
+//	{{ hash.SHA256 "Hello World" }} // Output: a591a6d40bf420404a011733cfb7b190d62c65bf0bcda32b57b277d9ad9f146e
+//
+// Example 2:
+//
+//	{{ hash.SHA256 "Hello World" "base64" }} // Output: pZGm1Av0IEBKARczz7exkNYsZb8LzaMrV7J32a2fFG4=
+func (ctx Hash) SHA256(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashSHA256]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashSHA256}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashSHA256); ok {
+		return fn(v, format...)
+	}
+	return sum(sha256.New(), v, format)
+}
+
+// SHA512 returns the SHA-512 checksum of v.
+//
+// Example:
+//
+//	{{ len ( hash.SHA512 "Hello World" ) }} // Output: 128
+func (ctx Hash) SHA512(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashSHA512]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashSHA512}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashSHA512); ok {
+		return fn(v, format...)
+	}
+	return sum(sha512.New(), v, format)
+}
+
+// FNV32a returns the 32-bit FNV-1a hash of v.
+//
+// Example:
+//
+//	{{ hash.FNV32a "Hello World" }} // Output: b3902527
+func (ctx Hash) FNV32a(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashFNV32a]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashFNV32a}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashFNV32a); ok {
+		return fn(v, format...)
+	}
+	return sum(fnv.New32a(), v, format)
+}
+
+// FNV64a returns the 64-bit FNV-1a hash of v.
+//
+// Example:
+//
+//	{{ hash.FNV64a "Hello World" }} // Output: 3d58dee72d4e0c27
+func (ctx Hash) FNV64a(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashFNV64a]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashFNV64a}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashFNV64a); ok {
+		return fn(v, format...)
+	}
+	return sum(fnv.New64a(), v, format)
+}
+
+// CRC32 returns the CRC-32 (IEEE) checksum of v.
+//
+// Example:
+//
+//	{{ hash.CRC32 "Hello World" }} // Output: 4a17b156
+func (ctx Hash) CRC32(v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashCRC32]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashCRC32}
+	}
+	if fn, ok := override[func(any, ...string) (string, error)](ctx.options, funcs.HashCRC32); ok {
+		return fn(v, format...)
+	}
+	return sum(crc32.NewIEEE(), v, format)
+}
+
+// HMACSHA256 returns the HMAC-SHA256 of v using key.
+//
+// Example:
+//
+//	{{ hash.HMACSHA256 "secret" "Hello World" "base64" }} // Output: gs4NL4IfoM5UR7ITBvIUyZJA/sxjh3eddRUUi73QxBU=
+func (ctx Hash) HMACSHA256(key, v any, format ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HashHMACSHA256]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HashHMACSHA256}
+	}
+	if fn, ok := override[func(any, any, ...string) (string, error)](ctx.options, funcs.HashHMACSHA256); ok {
+		return fn(key, v, format...)
+	}
+	k, err := toBytes(key)
+	if err != nil {
+		return "", err
+	}
+	return sum(hmac.New(sha256.New, k), v, format)
+}
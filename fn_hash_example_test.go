@@ -0,0 +1,91 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleHash_CRC32() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.CRC32 "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 4a17b156
+}
+
+func ExampleHash_FNV32a() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.FNV32a "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: b3902527
+}
+
+func ExampleHash_FNV64a() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.FNV64a "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3d58dee72d4e0c27
+}
+
+func ExampleHash_HMACSHA256() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.HMACSHA256 "secret" "Hello World" "base64" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: gs4NL4IfoM5UR7ITBvIUyZJA/sxjh3eddRUUi73QxBU=
+}
+
+func ExampleHash_MD5() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.MD5 "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: b10a8db164e0754105b7a99be72e3fe5
+}
+
+func ExampleHash_SHA1() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.SHA1 "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0a4d55a8d778e5022fab701977c5d840bbc486d0
+}
+
+func ExampleHash_SHA256() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.SHA256 "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a591a6d40bf420404a011733cfb7b190d62c65bf0bcda32b57b277d9ad9f146e
+}
+
+func ExampleHash_SHA256_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ hash.SHA256 "Hello World" "base64" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: pZGm1Av0IEBKARczz7exkNYsZb8LzaMrV7J32a2fFG4=
+}
+
+func ExampleHash_SHA512() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len ( hash.SHA512 "Hello World" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 128
+}
+
@@ -0,0 +1,45 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bytes", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := xtemplate.QuickExecute(`{{ hash.CRC32 ( json.Marshal "Hello World" ) }}`, nil, funcs.Safe)
+		if err != nil {
+			t.Fatalf("QuickExecute() error = %v", err)
+		}
+		if want := "22a3334a"; got != want {
+			t.Errorf("QuickExecute() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xtemplate.QuickExecute(`{{ hash.SHA256 "Hello World" "base32" }}`, nil, funcs.Hash)
+		var formatErr *xtemplate.UnknownDigestFormatError
+		if !errors.As(err, &formatErr) || formatErr.Format != "base32" {
+			t.Errorf("QuickExecute() error = %v, want UnknownDigestFormatError", err)
+		}
+	})
+
+	t.Run("weak hash not allowed", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xtemplate.QuickExecute(`{{ hash.MD5 "Hello World" }}`, nil, funcs.HashSHA256)
+		var notAllowedErr *xtemplate.FuncNotAllowedError
+		if !errors.As(err, &notAllowedErr) || notAllowedErr.Func != funcs.HashMD5 {
+			t.Errorf("QuickExecute() error = %v, want FuncNotAllowedError", err)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package xtemplate
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// HTML provides functions to escape HTML and to extract text from HTML.
+//
+// The namespace shares its name with the builtin html function of text/template. Called with arguments,
+// e.g. {{ .x | html }}, it escapes them like the builtin does. A bare {{ html }} prints nothing, like the builtin.
+type HTML rootContext
+
+// htmlNamespace is the value of html without arguments, it prints as an empty string.
+type htmlNamespace struct {
+	HTML
+}
+
+func (htmlNamespace) String() string {
+	return ""
+}
+
+// InvalidAttributeNameError is returned when an attribute name contains characters that are not allowed.
+type InvalidAttributeNameError struct {
+	Name string
+}
+
+func (e *InvalidAttributeNameError) Error() string {
+	return fmt.Sprintf("invalid attribute name %q", e.Name)
+}
+
+// EscapeString escapes the characters <, >, &, ' and " in s.
+//
+// Example:
+//
+//	{{ html.EscapeString "<b>Tom & Jerry</b>" }} // Output: &lt;b&gt;Tom &amp; Jerry&lt;/b&gt;
+func (ctx HTML) EscapeString(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HTMLEscapeString]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HTMLEscapeString}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.HTMLEscapeString); ok {
+		return fn(s)
+	}
+	return html.EscapeString(s), nil
+}
+
+// UnescapeString unescapes entities like &lt; and &#39; in s.
+//
+// Example:
+//
+//	{{ html.UnescapeString "Tom &amp; Jerry &#x1F600;" }} // Output: Tom & Jerry 😀
+func (ctx HTML) UnescapeString(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HTMLUnescapeString]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HTMLUnescapeString}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.HTMLUnescapeString); ok {
+		return fn(s)
+	}
+	return html.UnescapeString(s), nil
+}
+
+// StripTags converts HTML to plain text.
+// Tags and comments are removed, script and style elements are removed with their content and entities are
+// unescaped. Whitespace is collapsed, block elements like p, div and li and the br element start a new line.
+//
+// Example:
+//
+//	{{ html.StripTags "<p>Hi <b>all</b></p><script>x()</script>1 &lt; 2" | printf "%q" }} // Output: "Hi all\n1 < 2"
+func (ctx HTML) StripTags(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HTMLStripTags]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HTMLStripTags}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.HTMLStripTags); ok {
+		return fn(s)
+	}
+	return stripTags(s), nil
+}
+
+// Attr returns the attribute name="value" with value escaped, so it can be placed inside a tag.
+// The name must consist of letters, digits and the characters -, _, : and . only.
+//
+// Example:
+//
+//	{{ html.Attr "title" "\"quoted\" & <b>" }} // Output: title="&#34;quoted&#34; &amp; &lt;b&gt;"
+func (ctx HTML) Attr(name string, value any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.HTMLAttr]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.HTMLAttr}
+	}
+	if fn, ok := override[func(string, any) (string, error)](ctx.options, funcs.HTMLAttr); ok {
+		return fn(name, value)
+	}
+	if !isHTMLAttrName(name) {
+		return "", &InvalidAttributeNameError{Name: name}
+	}
+	return name + `="` + html.EscapeString(toString(value)) + `"`, nil
+}
+
+func isHTMLAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := range len(name) {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == ':':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+//nolint:gochecknoglobals // lookup table
+var htmlBlockElements = map[string]struct{}{
+	"address": {}, "article": {}, "aside": {}, "blockquote": {}, "br": {}, "dd": {}, "div": {}, "dl": {}, "dt": {},
+	"fieldset": {}, "figcaption": {}, "figure": {}, "footer": {}, "form": {}, "h1": {}, "h2": {}, "h3": {}, "h4": {},
+	"h5": {}, "h6": {}, "header": {}, "hr": {}, "li": {}, "main": {}, "nav": {}, "ol": {}, "p": {}, "pre": {},
+	"section": {}, "table": {}, "tr": {}, "ul": {},
+}
+
+// htmlText collects text, collapsing whitespace and line breaks.
+type htmlText struct {
+	b            strings.Builder
+	pendingSpace bool
+	lineStart    bool
+}
+
+func (t *htmlText) text(s string) {
+	for _, r := range html.UnescapeString(s) {
+		switch r {
+		case ' ', '\t', '\n', '\r', '\f':
+			t.pendingSpace = true
+			continue
+		}
+		if t.pendingSpace && !t.lineStart {
+			t.b.WriteByte(' ')
+		}
+		t.pendingSpace = false
+		t.lineStart = false
+		t.b.WriteRune(r)
+	}
+}
+
+func (t *htmlText) lineBreak(force bool) {
+	if t.b.Len() > 0 && (force || !t.lineStart) {
+		t.b.WriteByte('\n')
+	}
+	t.lineStart = true
+	t.pendingSpace = false
+}
+
+// htmlTagName returns the lower case name of the tag starting at s[0] == '<' and the tag length.
+// ok is false if s does not start with a tag.
+func htmlTagName(s string) (name string, closing bool, n int, ok bool) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && isHTMLTagNameChar(s[i], i > start) {
+		i++
+	}
+	if i == start {
+		return "", false, 0, false
+	}
+	name = strings.ToLower(s[start:i])
+	// skip attributes, quoted values may contain >
+	var quote byte
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return name, closing, i + 1, true
+		}
+	}
+	return name, closing, len(s), true
+}
+
+func isHTMLTagNameChar(c byte, digits bool) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || digits && c >= '0' && c <= '9'
+}
+
+func stripTags(s string) string {
+	t := &htmlText{b: strings.Builder{}, pendingSpace: false, lineStart: true}
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			t.text(s)
+			break
+		}
+		t.text(s[:i])
+		s = s[i:]
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				return strings.TrimSpace(t.b.String())
+			}
+			s = s[4+end+3:]
+			continue
+		case strings.HasPrefix(s, "<!") || strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return strings.TrimSpace(t.b.String())
+			}
+			s = s[end+1:]
+			continue
+		}
+		name, closing, n, ok := htmlTagName(s)
+		if !ok {
+			t.text("<")
+			s = s[1:]
+			continue
+		}
+		s = s[n:]
+		if !closing && (name == "script" || name == "style") {
+			end := strings.Index(strings.ToLower(s), "</"+name)
+			if end < 0 {
+				break
+			}
+			s = s[end:]
+			continue
+		}
+		if _, ok := htmlBlockElements[name]; ok {
+			t.lineBreak(name == "br")
+		}
+	}
+	return strings.TrimSpace(t.b.String())
+}
@@ -0,0 +1,46 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleHTML_Attr() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ html.Attr "title" "\"quoted\" & <b>" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: title="&#34;quoted&#34; &amp; &lt;b&gt;"
+}
+
+func ExampleHTML_EscapeString() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ html.EscapeString "<b>Tom & Jerry</b>" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: &lt;b&gt;Tom &amp; Jerry&lt;/b&gt;
+}
+
+func ExampleHTML_StripTags() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ html.StripTags "<p>Hi <b>all</b></p><script>x()</script>1 &lt; 2" | printf "%q" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "Hi all\n1 < 2"
+}
+
+func ExampleHTML_UnescapeString() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ html.UnescapeString "Tom &amp; Jerry &#x1F600;" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Tom & Jerry 😀
+}
+
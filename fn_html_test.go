@@ -0,0 +1,152 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestHTML_StripTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "inline elements",
+			data: "Hello <b>bold</b> and <a href=\"/x?a=1&amp;b=2\" title='a > b'>link</a>!",
+			want: "Hello bold and link!",
+		},
+		{
+			name: "block elements and whitespace",
+			data: "<html><head><title>T</title><style>p { color: red; }</style></head>\n<body>\n" +
+				"  <h1>Title</h1>\n  <p>First\n    paragraph</p>\n" +
+				"  <ul><li>one</li><li>two</li></ul>line<br>break<br/><br/>end\n" +
+				"</body></html>",
+			want: "T\nTitle\nFirst paragraph\none\ntwo\nline\nbreak\n\nend",
+		},
+		{
+			name: "comments, scripts and doctype",
+			data: "<!DOCTYPE html><!-- <p>hidden</p> --><SCRIPT type=\"text/javascript\">if (a < b) {}</SCRIPT>text",
+			want: "text",
+		},
+		{
+			name: "entities",
+			data: "<p>5 &lt; 6 &amp;&amp; caf&eacute; &#169;</p>",
+			want: "5 < 6 && café ©",
+		},
+		{
+			name: "not a tag",
+			data: "a < b and c <3",
+			want: "a < b and c <3",
+		},
+		{
+			name: "unterminated comment",
+			data: "text <!-- open",
+			want: "text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(`{{ html.StripTags . }}`, tt.data, funcs.HTML)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTML_Attr(t *testing.T) {
+	t.Parallel()
+
+	got, err := xtemplate.QuickExecute(`<a {{ html.Attr "data-id" . }}>`, `1" onclick="x`, funcs.HTML)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := `<a data-id="1&#34; onclick=&#34;x">`; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+
+	for _, name := range []string{"", "on click", `x"`, "1a", "a>"} {
+		_, err := xtemplate.QuickExecute(`{{ html.Attr . "v" }}`, name, funcs.HTML)
+		var attrErr *xtemplate.InvalidAttributeNameError
+		if !errors.As(err, &attrErr) || attrErr.Name != name {
+			t.Errorf("Attr(%q) error = %v, want InvalidAttributeNameError", name, err)
+		}
+	}
+}
+
+func TestHTML_Builtin(t *testing.T) {
+	t.Parallel()
+
+	// without the namespace the builtin html function is used
+	got, err := xtemplate.QuickExecute(`{{ html "<b>" }}`, nil, funcs.Strings)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "&lt;b&gt;"; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+
+	const tmpl = `{{ html.EscapeString "<b>" }}|{{ html.UnescapeString "&lt;b&gt;" }}`
+	got, err = xtemplate.QuickExecute(tmpl, nil, funcs.HTML)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "&lt;b&gt;|<b>"; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ html.EscapeString "<b>" }}`, nil, funcs.HTMLStripTags)
+	var notAllowedErr *xtemplate.FuncNotAllowedError
+	if !errors.As(err, &notAllowedErr) || notAllowedErr.Func != funcs.HTMLEscapeString {
+		t.Errorf("QuickExecute() error = %v, want FuncNotAllowedError", err)
+	}
+}
+
+func TestHTML_BuiltinWithNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{name: "pipeline", tmpl: `{{ "<a>" | html }}`, data: nil, want: "&lt;a&gt;"},
+		{
+			name: "data",
+			tmpl: `{{ .x | html }}`,
+			data: map[string]any{"x": `"Tom" & 'Jerry'`},
+			want: "&#34;Tom&#34; &amp; &#39;Jerry&#39;",
+		},
+		{name: "multiple arguments", tmpl: `{{ html "<a>" 1 }}`, data: nil, want: "&lt;a&gt;1"},
+		{name: "namespace", tmpl: `{{ html.EscapeString "<a>" }}`, data: nil, want: "&lt;a&gt;"},
+		{name: "no arguments", tmpl: `a{{ html }}b`, data: nil, want: "ab"},
+		{name: "no arguments in pipeline", tmpl: `{{ html | printf "%s|" }}`, data: nil, want: "|"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Safe)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,9 @@ func (ctx JSON) Compact(dst *bytes.Buffer, src []byte) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.JSONCompact]; !ok {
 		return &FuncNotAllowedError{Func: funcs.JSONCompact}
 	}
+	if fn, ok := override[func(*bytes.Buffer, []byte) error](ctx.options, funcs.JSONCompact); ok {
+		return fn(dst, src)
+	}
 	return json.Compact(dst, src)
 }
 
@@ -41,6 +44,9 @@ func (ctx JSON) HTMLEscape(dst *bytes.Buffer, src []byte) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.JSONHTMLEscape]; !ok {
 		return &FuncNotAllowedError{Func: funcs.JSONHTMLEscape}
 	}
+	if fn, ok := override[func(*bytes.Buffer, []byte) error](ctx.options, funcs.JSONHTMLEscape); ok {
+		return fn(dst, src)
+	}
 	json.HTMLEscape(dst, src)
 	return nil
 }
@@ -62,6 +68,9 @@ func (ctx JSON) Indent(dst *bytes.Buffer, src []byte, prefix, indent string) err
 	if _, ok := ctx.allowedFunctionSet[funcs.JSONIndent]; !ok {
 		return &FuncNotAllowedError{Func: funcs.JSONIndent}
 	}
+	if fn, ok := override[func(*bytes.Buffer, []byte, string, string) error](ctx.options, funcs.JSONIndent); ok {
+		return fn(dst, src, prefix, indent)
+	}
 	return json.Indent(dst, src, prefix, indent)
 }
 
@@ -73,6 +82,12 @@ func (ctx JSON) Indent(dst *bytes.Buffer, src []byte, prefix, indent string) err
 //	{{ $buf := json.Marshal $dict }}
 //	{{ conv.ToString $buf }} // Output: {"foo":"bar"}
 func (ctx JSON) Marshal(v any) ([]byte, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.JSONMarshal]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.JSONMarshal}
+	}
+	if fn, ok := override[func(any) ([]byte, error)](ctx.options, funcs.JSONMarshal); ok {
+		return fn(v)
+	}
 	if m, ok := v.(map[any]any); ok {
 		// json.Marshal doesn't support map[any]any, so convert to map[string]any
 		m2 := make(map[string]any, len(m))
@@ -86,9 +101,6 @@ func (ctx JSON) Marshal(v any) ([]byte, error) {
 		}
 		v = m2
 	}
-	if _, ok := ctx.allowedFunctionSet[funcs.JSONMarshal]; !ok {
-		return nil, &FuncNotAllowedError{Func: funcs.JSONMarshal}
-	}
 	return json.Marshal(v)
 }
 
@@ -103,6 +115,9 @@ func (ctx JSON) MarshalIndent(v any, prefix, indent string) ([]byte, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.JSONMarshalIndent]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.JSONMarshalIndent}
 	}
+	if fn, ok := override[func(any, string, string) ([]byte, error)](ctx.options, funcs.JSONMarshalIndent); ok {
+		return fn(v, prefix, indent)
+	}
 	return json.MarshalIndent(v, prefix, indent)
 }
 
@@ -117,6 +132,9 @@ func (ctx JSON) Unmarshal(data []byte, v any) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.JSONUnmarshal]; !ok {
 		return &FuncNotAllowedError{Func: funcs.JSONUnmarshal}
 	}
+	if fn, ok := override[func([]byte, any) error](ctx.options, funcs.JSONUnmarshal); ok {
+		return fn(data, v)
+	}
 	return json.Unmarshal(data, v)
 }
 
@@ -129,5 +147,8 @@ func (ctx JSON) Valid(data []byte) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.JSONValid]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.JSONValid}
 	}
+	if fn, ok := override[func([]byte) (bool, error)](ctx.options, funcs.JSONValid); ok {
+		return fn(data)
+	}
 	return json.Valid(data), nil
 }
@@ -0,0 +1,565 @@
+package xtemplate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Math provides arithmetic functions.
+//
+// The functions accept the same numeric inputs as the conv functions (numbers, numeric strings and bools).
+// If all inputs are integers the calculation is done with int64 values and the result is an int64,
+// otherwise the calculation is done with float64 values and the result is a float64.
+// Integer overflows are reported with an OverflowError instead of wrapping silently.
+type Math rootContext
+
+// ErrDivisionByZero is returned when dividing by zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// ErrNotANumber is returned when a calculation results in NaN.
+var ErrNotANumber = errors.New("result is not a number")
+
+// OverflowError is returned when the result of a calculation does not fit into the result type.
+type OverflowError struct {
+	Func funcs.Func
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("%s.%s: result overflows", e.Func.Namespace, e.Func.Name)
+}
+
+// number is either an int64 or a float64.
+type number struct {
+	i       int64
+	f       float64
+	isFloat bool
+}
+
+func (n number) float() float64 {
+	if n.isFloat {
+		return n.f
+	}
+	return float64(n.i)
+}
+
+func (n number) value() any {
+	if n.isFloat {
+		return n.f
+	}
+	return n.i
+}
+
+func intNumber(i int64) number {
+	return number{i: i, f: 0, isFloat: false}
+}
+
+func floatNumber(f float64) number {
+	return number{i: 0, f: f, isFloat: true}
+}
+
+func toNumber(v any) (number, error) {
+	if str, ok := v.(string); ok {
+		str = strings.ReplaceAll(str, ",", "")
+		if i, err := strconv.ParseInt(str, 0, 64); err == nil {
+			return intNumber(i), nil
+		}
+		f, err := strToFloat64(str)
+		if err != nil {
+			return number{}, err
+		}
+		return floatNumber(f), nil
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(v))
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return floatNumber(val.Float()), nil
+	default:
+		i, err := toInt64(v)
+		if err != nil {
+			return number{}, err
+		}
+		return intNumber(i), nil
+	}
+}
+
+func toNumbers(in []any) ([]number, error) {
+	// allow a single slice argument
+	if len(in) == 1 {
+		if sl := reflect.ValueOf(in[0]); sl.Kind() == reflect.Slice {
+			in = make([]any, sl.Len())
+			for i := range in {
+				in[i] = sl.Index(i).Interface()
+			}
+		}
+	}
+	out := make([]number, len(in))
+	for i, v := range in {
+		n, err := toNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// checkFloat reports NaN results and overflows to infinity.
+func checkFloat(f funcs.Func, r float64) (number, error) {
+	if math.IsNaN(r) {
+		return number{}, ErrNotANumber
+	}
+	if math.IsInf(r, 0) {
+		return number{}, &OverflowError{Func: f}
+	}
+	return floatNumber(r), nil
+}
+
+func addNumbers(f funcs.Func, a, b number) (number, error) {
+	if a.isFloat || b.isFloat {
+		return checkFloat(f, a.float()+b.float())
+	}
+	r := a.i + b.i
+	if (a.i > 0 && b.i > 0 && r < 0) || (a.i < 0 && b.i < 0 && r >= 0) {
+		return number{}, &OverflowError{Func: f}
+	}
+	return intNumber(r), nil
+}
+
+func subNumbers(f funcs.Func, a, b number) (number, error) {
+	if a.isFloat || b.isFloat {
+		return checkFloat(f, a.float()-b.float())
+	}
+	r := a.i - b.i
+	if (b.i > 0 && r > a.i) || (b.i < 0 && r < a.i) {
+		return number{}, &OverflowError{Func: f}
+	}
+	return intNumber(r), nil
+}
+
+func mulNumbers(f funcs.Func, a, b number) (number, error) {
+	if a.isFloat || b.isFloat {
+		return checkFloat(f, a.float()*b.float())
+	}
+	if a.i == 0 || b.i == 0 {
+		return intNumber(0), nil
+	}
+	r := a.i * b.i
+	if r/b.i != a.i || (a.i == -1 && b.i == math.MinInt64) || (b.i == -1 && a.i == math.MinInt64) {
+		return number{}, &OverflowError{Func: f}
+	}
+	return intNumber(r), nil
+}
+
+func (ctx Math) binary(f funcs.Func, a, b any, op func(funcs.Func, number, number) (number, error)) (any, error) {
+	x, err := toNumber(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toNumber(b)
+	if err != nil {
+		return nil, err
+	}
+	r, err := op(f, x, y)
+	if err != nil {
+		return nil, err
+	}
+	return r.value(), nil
+}
+
+// Add returns a + b.
+//
+// Example 1:
+//
+//	{{ math.Add 1 2 }} // Output: 3
+//
+// Example 2:
+//
+//	{{ math.Add "1.5" 2 }} // Output: 3.5
+func (ctx Math) Add(a, b any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathAdd]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathAdd}
+	}
+	if fn, ok := override[func(any, any) (any, error)](ctx.options, funcs.MathAdd); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(funcs.MathAdd, a, b, addNumbers)
+}
+
+// Sub returns a - b.
+//
+// Example:
+//
+//	{{ math.Sub 10 4 }} // Output: 6
+func (ctx Math) Sub(a, b any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathSub]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathSub}
+	}
+	if fn, ok := override[func(any, any) (any, error)](ctx.options, funcs.MathSub); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(funcs.MathSub, a, b, subNumbers)
+}
+
+// Mul returns a * b.
+//
+// Example:
+//
+//	{{ math.Mul 6 7 }} // Output: 42
+func (ctx Math) Mul(a, b any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathMul]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathMul}
+	}
+	if fn, ok := override[func(any, any) (any, error)](ctx.options, funcs.MathMul); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(funcs.MathMul, a, b, mulNumbers)
+}
+
+// Div returns a / b. Integers are divided with truncation towards zero.
+//
+// Example 1:
+//
+//	{{ math.Div 7 2 }} // Output: 3
+//
+// Example 2:
+//
+//	{{ math.Div 7.0 2 }} // Output: 3.5
+func (ctx Math) Div(a, b any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathDiv]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathDiv}
+	}
+	if fn, ok := override[func(any, any) (any, error)](ctx.options, funcs.MathDiv); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(funcs.MathDiv, a, b, func(f funcs.Func, x, y number) (number, error) {
+		if y.float() == 0 {
+			return number{}, ErrDivisionByZero
+		}
+		if x.isFloat || y.isFloat {
+			return checkFloat(f, x.float()/y.float())
+		}
+		if x.i == math.MinInt64 && y.i == -1 {
+			return number{}, &OverflowError{Func: f}
+		}
+		return intNumber(x.i / y.i), nil
+	})
+}
+
+// Mod returns the remainder of a / b. The result has the sign of a.
+//
+// Example:
+//
+//	{{ math.Mod 7 3 }} // Output: 1
+func (ctx Math) Mod(a, b any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathMod]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathMod}
+	}
+	if fn, ok := override[func(any, any) (any, error)](ctx.options, funcs.MathMod); ok {
+		return fn(a, b)
+	}
+	return ctx.binary(funcs.MathMod, a, b, func(f funcs.Func, x, y number) (number, error) {
+		if y.float() == 0 {
+			return number{}, ErrDivisionByZero
+		}
+		if x.isFloat || y.isFloat {
+			return checkFloat(f, math.Mod(x.float(), y.float()))
+		}
+		return intNumber(x.i % y.i), nil
+	})
+}
+
+// Abs returns the absolute value of x.
+//
+// Example:
+//
+//	{{ math.Abs -42 }} // Output: 42
+func (ctx Math) Abs(x any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathAbs]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathAbs}
+	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.MathAbs); ok {
+		return fn(x)
+	}
+	n, err := toNumber(x)
+	if err != nil {
+		return nil, err
+	}
+	if n.isFloat {
+		return math.Abs(n.f), nil
+	}
+	if n.i == math.MinInt64 {
+		return nil, &OverflowError{Func: funcs.MathAbs}
+	}
+	if n.i < 0 {
+		return -n.i, nil
+	}
+	return n.i, nil
+}
+
+func extreme(values []any, better func(a, b number) bool) (any, error) {
+	numbers, err := toNumbers(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, ErrAtLeastOneArgumentIsRequired
+	}
+	isFloat := false
+	result := numbers[0]
+	for _, n := range numbers {
+		isFloat = isFloat || n.isFloat
+		if math.IsNaN(n.float()) {
+			return nil, ErrNotANumber
+		}
+		if better(n, result) {
+			result = n
+		}
+	}
+	if isFloat {
+		return result.float(), nil
+	}
+	return result.i, nil
+}
+
+func lessNumber(a, b number) bool {
+	if a.isFloat || b.isFloat {
+		return a.float() < b.float()
+	}
+	return a.i < b.i
+}
+
+// Min returns the smallest of the given values. The values can also be passed as a single slice.
+//
+// Example 1:
+//
+//	{{ math.Min 3 1 2 }} // Output: 1
+//
+// Example 2:
+//
+//	{{ math.Min ( slice.New 3 1.5 2 ) }} // Output: 1.5
+func (ctx Math) Min(values ...any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathMin]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathMin}
+	}
+	if fn, ok := override[func(...any) (any, error)](ctx.options, funcs.MathMin); ok {
+		return fn(values...)
+	}
+	return extreme(values, lessNumber)
+}
+
+// Max returns the largest of the given values. The values can also be passed as a single slice.
+//
+// Example:
+//
+//	{{ math.Max 3 1 2 }} // Output: 3
+func (ctx Math) Max(values ...any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathMax]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathMax}
+	}
+	if fn, ok := override[func(...any) (any, error)](ctx.options, funcs.MathMax); ok {
+		return fn(values...)
+	}
+	return extreme(values, func(a, b number) bool {
+		return lessNumber(b, a)
+	})
+}
+
+// Sum returns the sum of the given values. The values can also be passed as a single slice.
+//
+// Example 1:
+//
+//	{{ math.Sum 1 2 3 }} // Output: 6
+//
+// Example 2:
+//
+//	{{ math.Sum ( slice.New 1 2.5 "3" ) }} // Output: 6.5
+func (ctx Math) Sum(values ...any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathSum]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathSum}
+	}
+	if fn, ok := override[func(...any) (any, error)](ctx.options, funcs.MathSum); ok {
+		return fn(values...)
+	}
+	numbers, err := toNumbers(values)
+	if err != nil {
+		return nil, err
+	}
+	result := intNumber(0)
+	for _, n := range numbers {
+		result, err = addNumbers(funcs.MathSum, result, n)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result.value(), nil
+}
+
+// Pow returns x**y. If x and y are integers and y is not negative the result is an integer.
+//
+// Example 1:
+//
+//	{{ math.Pow 2 10 }} // Output: 1024
+//
+// Example 2:
+//
+//	{{ math.Pow 2 -1 }} // Output: 0.5
+func (ctx Math) Pow(x, y any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathPow]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathPow}
+	}
+	if fn, ok := override[func(any, any) (any, error)](ctx.options, funcs.MathPow); ok {
+		return fn(x, y)
+	}
+	return ctx.binary(funcs.MathPow, x, y, func(f funcs.Func, base, exp number) (number, error) {
+		if base.isFloat || exp.isFloat || exp.i < 0 {
+			return checkFloat(f, math.Pow(base.float(), exp.float()))
+		}
+		// exponentiation by squaring, the base is only squared if a factor remains
+		result := intNumber(1)
+		for e := exp.i; e > 0; e >>= 1 {
+			var err error
+			if e&1 == 1 {
+				if result, err = mulNumbers(f, result, base); err != nil {
+					return number{}, err
+				}
+			}
+			if e > 1 {
+				if base, err = mulNumbers(f, base, base); err != nil {
+					return number{}, err
+				}
+			}
+		}
+		return result, nil
+	})
+}
+
+// Sqrt returns the square root of x.
+//
+// Example:
+//
+//	{{ math.Sqrt 16 }} // Output: 4
+func (ctx Math) Sqrt(x any) (float64, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathSqrt]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.MathSqrt}
+	}
+	if fn, ok := override[func(any) (float64, error)](ctx.options, funcs.MathSqrt); ok {
+		return fn(x)
+	}
+	return ctx.unaryFloat(funcs.MathSqrt, x, math.Sqrt)
+}
+
+// Floor returns the greatest integer value less than or equal to x.
+//
+// Example:
+//
+//	{{ math.Floor 1.7 }} // Output: 1
+func (ctx Math) Floor(x any) (float64, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathFloor]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.MathFloor}
+	}
+	if fn, ok := override[func(any) (float64, error)](ctx.options, funcs.MathFloor); ok {
+		return fn(x)
+	}
+	return ctx.unaryFloat(funcs.MathFloor, x, math.Floor)
+}
+
+// Ceil returns the least integer value greater than or equal to x.
+//
+// Example:
+//
+//	{{ math.Ceil 1.2 }} // Output: 2
+func (ctx Math) Ceil(x any) (float64, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathCeil]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.MathCeil}
+	}
+	if fn, ok := override[func(any) (float64, error)](ctx.options, funcs.MathCeil); ok {
+		return fn(x)
+	}
+	return ctx.unaryFloat(funcs.MathCeil, x, math.Ceil)
+}
+
+// Round returns x rounded to the given number of decimal places (default 0), rounding half away from zero.
+//
+// Example 1:
+//
+//	{{ math.Round 2.5 }} // Output: 3
+//
+// Example 2:
+//
+//	{{ math.Round 3.14159 2 }} // Output: 3.14
+func (ctx Math) Round(x any, places ...int) (float64, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathRound]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.MathRound}
+	}
+	if fn, ok := override[func(any, ...int) (float64, error)](ctx.options, funcs.MathRound); ok {
+		return fn(x, places...)
+	}
+	if len(places) > 1 {
+		return 0, OnlyOneArgumentIsAllowedError{}
+	}
+	return ctx.unaryFloat(funcs.MathRound, x, func(f float64) float64 {
+		if len(places) == 0 || places[0] == 0 {
+			return math.Round(f)
+		}
+		//nolint:mnd // base 10
+		p := math.Pow(10, float64(places[0]))
+		return math.Round(f*p) / p
+	})
+}
+
+func (ctx Math) unaryFloat(f funcs.Func, x any, op func(float64) float64) (float64, error) {
+	n, err := toNumber(x)
+	if err != nil {
+		return 0, err
+	}
+	r, err := checkFloat(f, op(n.float()))
+	if err != nil {
+		return 0, err
+	}
+	return r.f, nil
+}
+
+// Clamp returns x limited to the range [lower, upper].
+//
+// Example 1:
+//
+//	{{ math.Clamp 15 0 10 }} // Output: 10
+//
+// Example 2:
+//
+//	{{ math.Clamp -5 0 10 }} // Output: 0
+func (ctx Math) Clamp(x, lower, upper any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.MathClamp]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.MathClamp}
+	}
+	if fn, ok := override[func(any, any, any) (any, error)](ctx.options, funcs.MathClamp); ok {
+		return fn(x, lower, upper)
+	}
+	numbers, err := toNumbers([]any{x, lower, upper})
+	if err != nil {
+		return nil, err
+	}
+	n, lo, hi := numbers[0], numbers[1], numbers[2]
+	if lessNumber(hi, lo) {
+		//nolint:err113 // allow dynamic error
+		return nil, fmt.Errorf("lower bound %v is greater than upper bound %v", lo.value(), hi.value())
+	}
+	isFloat := n.isFloat || lo.isFloat || hi.isFloat
+	switch {
+	case lessNumber(n, lo):
+		n = lo
+	case lessNumber(hi, n):
+		n = hi
+	}
+	if isFloat {
+		return n.float(), nil
+	}
+	return n.i, nil
+}
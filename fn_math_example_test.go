@@ -0,0 +1,208 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleMath_Abs() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Abs -42 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 42
+}
+
+func ExampleMath_Add() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Add 1 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3
+}
+
+func ExampleMath_Add_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Add "1.5" 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3.5
+}
+
+func ExampleMath_Ceil() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Ceil 1.2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2
+}
+
+func ExampleMath_Clamp() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Clamp 15 0 10 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 10
+}
+
+func ExampleMath_Clamp_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Clamp -5 0 10 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0
+}
+
+func ExampleMath_Div() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Div 7 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3
+}
+
+func ExampleMath_Div_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Div 7.0 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3.5
+}
+
+func ExampleMath_Floor() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Floor 1.7 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1
+}
+
+func ExampleMath_Max() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Max 3 1 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3
+}
+
+func ExampleMath_Min() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Min 3 1 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1
+}
+
+func ExampleMath_Min_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Min ( slice.New 3 1.5 2 ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.5
+}
+
+func ExampleMath_Mod() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Mod 7 3 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1
+}
+
+func ExampleMath_Mul() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Mul 6 7 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 42
+}
+
+func ExampleMath_Pow() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Pow 2 10 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1024
+}
+
+func ExampleMath_Pow_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Pow 2 -1 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 0.5
+}
+
+func ExampleMath_Round() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Round 2.5 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3
+}
+
+func ExampleMath_Round_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Round 3.14159 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3.14
+}
+
+func ExampleMath_Sqrt() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Sqrt 16 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 4
+}
+
+func ExampleMath_Sub() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Sub 10 4 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 6
+}
+
+func ExampleMath_Sum() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Sum 1 2 3 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 6
+}
+
+func ExampleMath_Sum_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ math.Sum ( slice.New 1 2.5 "3" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 6.5
+}
+
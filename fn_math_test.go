@@ -0,0 +1,119 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestMath_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr error
+	}{
+		{
+			name:    "add overflow",
+			tmpl:    `{{ math.Add 9223372036854775807 1 }}`,
+			wantErr: &xtemplate.OverflowError{Func: funcs.MathAdd},
+		},
+		{
+			name:    "sub overflow",
+			tmpl:    `{{ math.Sub -9223372036854775808 1 }}`,
+			wantErr: &xtemplate.OverflowError{Func: funcs.MathSub},
+		},
+		{
+			name:    "mul overflow",
+			tmpl:    `{{ math.Mul 4611686018427387904 2 }}`,
+			wantErr: &xtemplate.OverflowError{Func: funcs.MathMul},
+		},
+		{
+			name:    "pow overflow",
+			tmpl:    `{{ math.Pow 2 63 }}`,
+			wantErr: &xtemplate.OverflowError{Func: funcs.MathPow},
+		},
+		{
+			name:    "sum overflow",
+			tmpl:    `{{ math.Sum 9223372036854775807 1 }}`,
+			wantErr: &xtemplate.OverflowError{Func: funcs.MathSum},
+		},
+		{
+			name:    "float overflow",
+			tmpl:    `{{ math.Mul 1e308 10.0 }}`,
+			wantErr: &xtemplate.OverflowError{Func: funcs.MathMul},
+		},
+		{
+			name:    "division by zero",
+			tmpl:    `{{ math.Div 1 0 }}`,
+			wantErr: xtemplate.ErrDivisionByZero,
+		},
+		{
+			name:    "modulo by zero",
+			tmpl:    `{{ math.Mod 1.5 0 }}`,
+			wantErr: xtemplate.ErrDivisionByZero,
+		},
+		{
+			name:    "not a number",
+			tmpl:    `{{ math.Sqrt -1 }}`,
+			wantErr: xtemplate.ErrNotANumber,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Math)
+			var overflowErr *xtemplate.OverflowError
+			if errors.As(tt.wantErr, &overflowErr) {
+				var gotErr *xtemplate.OverflowError
+				if !errors.As(err, &gotErr) || *gotErr != *overflowErr {
+					t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMath_Pow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{tmpl: `{{ math.Pow -1 3 }}`, want: "-1"},
+		{tmpl: `{{ math.Pow -1 4 }}`, want: "1"},
+		{tmpl: `{{ math.Pow -1 9223372036854775807 }}`, want: "-1"},
+		{tmpl: `{{ math.Pow -2 3 }}`, want: "-8"},
+		{tmpl: `{{ math.Pow -3 4 }}`, want: "81"},
+		{tmpl: `{{ math.Pow -2 63 }}`, want: "-9223372036854775808"},
+		{tmpl: `{{ math.Pow 3 0 }}`, want: "1"},
+		{tmpl: `{{ math.Pow 0 5 }}`, want: "0"},
+		{tmpl: `{{ math.Pow 1 9223372036854775807 }}`, want: "1"},
+		{tmpl: `{{ math.Pow 7 22 }}`, want: "3909821048582988049"},
+		{tmpl: `{{ math.Pow 4294967296 1 }}`, want: "4294967296"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Math)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package xtemplate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Net provides functions to work with IP addresses and prefixes (CIDR notation).
+//
+// Addresses and prefixes can be passed as strings or as values returned by other net functions.
+// No function performs DNS lookups or any other network access.
+type Net rootContext
+
+// ErrAddrOutOfRange is returned when an address would be outside its prefix or address family.
+var ErrAddrOutOfRange = errors.New("address out of range")
+
+// ErrInvalidPrefixLength is returned when a prefix cannot be split into prefixes of the requested length.
+var ErrInvalidPrefixLength = errors.New("invalid prefix length")
+
+// maxSubnetBits limits the number of prefixes returned by Subnet to 65536.
+const maxSubnetBits = 16
+
+// HostPortResult is the result type for the SplitHostPort function.
+type HostPortResult struct {
+	Host string
+	Port string
+}
+
+func toAddr(v any) (netip.Addr, error) {
+	switch tv := v.(type) {
+	case netip.Addr:
+		return tv, nil
+	case *netip.Addr:
+		if tv != nil {
+			return *tv, nil
+		}
+	}
+	return netip.ParseAddr(toString(v))
+}
+
+func toPrefix(v any) (netip.Prefix, error) {
+	switch tv := v.(type) {
+	case netip.Prefix:
+		return tv, nil
+	case *netip.Prefix:
+		if tv != nil {
+			return *tv, nil
+		}
+	}
+	return netip.ParsePrefix(toString(v))
+}
+
+// addrToInt returns the address as an integer.
+func addrToInt(a netip.Addr) *big.Int {
+	b := a.AsSlice()
+	return new(big.Int).SetBytes(b)
+}
+
+// intToAddr converts an integer to an address of the same family as like.
+func intToAddr(i *big.Int, like netip.Addr) (netip.Addr, error) {
+	if i.Sign() < 0 || i.BitLen() > like.BitLen() {
+		return netip.Addr{}, ErrAddrOutOfRange
+	}
+	b := make([]byte, like.BitLen()/8) //nolint:mnd // bits per byte
+	i.FillBytes(b)
+	a, _ := netip.AddrFromSlice(b)
+	return a.WithZone(like.Zone()), nil
+}
+
+// ParseAddr parses s as an IPv4 or IPv6 address.
+//
+// Example:
+//
+//	{{ net.ParseAddr "2001:0db8::0001" }} // Output: 2001:db8::1
+func (ctx Net) ParseAddr(s string) (netip.Addr, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetParseAddr]; !ok {
+		return netip.Addr{}, &FuncNotAllowedError{Func: funcs.NetParseAddr}
+	}
+	if fn, ok := override[func(string) (netip.Addr, error)](ctx.options, funcs.NetParseAddr); ok {
+		return fn(s)
+	}
+	return netip.ParseAddr(s)
+}
+
+// ParsePrefix parses s as a prefix in CIDR notation, the address is not masked.
+//
+// Example:
+//
+//	{{ ( net.ParsePrefix "10.1.2.3/8" ).Masked }} // Output: 10.0.0.0/8
+func (ctx Net) ParsePrefix(s string) (netip.Prefix, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetParsePrefix]; !ok {
+		return netip.Prefix{}, &FuncNotAllowedError{Func: funcs.NetParsePrefix}
+	}
+	if fn, ok := override[func(string) (netip.Prefix, error)](ctx.options, funcs.NetParsePrefix); ok {
+		return fn(s)
+	}
+	return netip.ParsePrefix(s)
+}
+
+// Contains reports whether the prefix contains v, which can be an address or a prefix.
+//
+// Example 1:
+//
+//	{{ net.Contains "10.0.0.0/8" "10.1.2.3" }} // Output: true
+//
+// Example 2:
+//
+//	{{ net.Contains "10.0.0.0/16" "10.0.0.0/8" }} // Output: false
+func (ctx Net) Contains(prefix, v any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetContains]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.NetContains}
+	}
+	if fn, ok := override[func(any, any) (bool, error)](ctx.options, funcs.NetContains); ok {
+		return fn(prefix, v)
+	}
+	p, err := toPrefix(prefix)
+	if err != nil {
+		return false, err
+	}
+	if other, err := toPrefix(v); err == nil {
+		return other.Bits() >= p.Bits() && p.Contains(other.Addr()), nil
+	}
+	a, err := toAddr(v)
+	if err != nil {
+		return false, err
+	}
+	return p.Contains(a), nil
+}
+
+// Host returns the nth address in the prefix, counting from the network address.
+// A negative n counts from the last address, -1 is the last address.
+//
+// Example 1:
+//
+//	{{ net.Host "192.168.1.0/24" 10 }} // Output: 192.168.1.10
+//
+// Example 2:
+//
+//	{{ net.Host "192.168.1.0/24" -2 }} // Output: 192.168.1.254
+func (ctx Net) Host(prefix any, n int) (netip.Addr, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetHost]; !ok {
+		return netip.Addr{}, &FuncNotAllowedError{Func: funcs.NetHost}
+	}
+	if fn, ok := override[func(any, int) (netip.Addr, error)](ctx.options, funcs.NetHost); ok {
+		return fn(prefix, n)
+	}
+	p, err := toPrefix(prefix)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	p = p.Masked()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(p.Addr().BitLen()-p.Bits())) //nolint:gosec // bits are positive
+	offset := big.NewInt(int64(n))
+	if n < 0 {
+		offset.Add(offset, size)
+	}
+	if offset.Sign() < 0 || offset.Cmp(size) >= 0 {
+		return netip.Addr{}, ErrAddrOutOfRange
+	}
+	return intToAddr(offset.Add(offset, addrToInt(p.Addr())), p.Addr())
+}
+
+// Subnet splits the prefix into prefixes that are newBits longer.
+// At most 65536 prefixes are returned.
+//
+// Example:
+//
+//	{{ net.Subnet "10.0.0.0/16" 2 }} // Output: [10.0.0.0/18 10.0.64.0/18 10.0.128.0/18 10.0.192.0/18]
+func (ctx Net) Subnet(prefix any, newBits int) ([]netip.Prefix, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetSubnet]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.NetSubnet}
+	}
+	if fn, ok := override[func(any, int) ([]netip.Prefix, error)](ctx.options, funcs.NetSubnet); ok {
+		return fn(prefix, newBits)
+	}
+	p, err := toPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	p = p.Masked()
+	bits := p.Bits() + newBits
+	if newBits < 0 || bits > p.Addr().BitLen() {
+		return nil, ErrInvalidPrefixLength
+	}
+	if newBits > maxSubnetBits {
+		return nil, fmt.Errorf("%w: more than %d prefixes", ErrInvalidPrefixLength, 1<<maxSubnetBits)
+	}
+	step := new(big.Int).Lsh(big.NewInt(1), uint(p.Addr().BitLen()-bits)) //nolint:gosec // bits are positive
+	current := addrToInt(p.Addr())
+	out := make([]netip.Prefix, 1<<newBits)
+	for i := range out {
+		a, err := intToAddr(current, p.Addr())
+		if err != nil {
+			return nil, err
+		}
+		out[i] = netip.PrefixFrom(a, bits)
+		current.Add(current, step)
+	}
+	return out, nil
+}
+
+// Next returns the address following addr.
+//
+// Example:
+//
+//	{{ net.Next "10.0.0.255" }} // Output: 10.0.1.0
+func (ctx Net) Next(addr any) (netip.Addr, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetNext]; !ok {
+		return netip.Addr{}, &FuncNotAllowedError{Func: funcs.NetNext}
+	}
+	if fn, ok := override[func(any) (netip.Addr, error)](ctx.options, funcs.NetNext); ok {
+		return fn(addr)
+	}
+	a, err := toAddr(addr)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if a = a.Next(); !a.IsValid() {
+		return netip.Addr{}, ErrAddrOutOfRange
+	}
+	return a, nil
+}
+
+// Prev returns the address preceding addr.
+//
+// Example:
+//
+//	{{ net.Prev "2001:db8::1:0" }} // Output: 2001:db8::ffff
+func (ctx Net) Prev(addr any) (netip.Addr, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetPrev]; !ok {
+		return netip.Addr{}, &FuncNotAllowedError{Func: funcs.NetPrev}
+	}
+	if fn, ok := override[func(any) (netip.Addr, error)](ctx.options, funcs.NetPrev); ok {
+		return fn(addr)
+	}
+	a, err := toAddr(addr)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if a = a.Prev(); !a.IsValid() {
+		return netip.Addr{}, ErrAddrOutOfRange
+	}
+	return a, nil
+}
+
+// IsIPv4 reports whether v is an IPv4 address, it returns false for values that are not addresses.
+//
+// Example 1:
+//
+//	{{ net.IsIPv4 "10.0.0.1" }} // Output: true
+//
+// Example 2:
+//
+//	{{ net.IsIPv4 "::ffff:10.0.0.1" }} // Output: false
+func (ctx Net) IsIPv4(v any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetIsIPv4]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.NetIsIPv4}
+	}
+	if fn, ok := override[func(any) (bool, error)](ctx.options, funcs.NetIsIPv4); ok {
+		return fn(v)
+	}
+	a, err := toAddr(v)
+	return err == nil && a.Is4(), nil
+}
+
+// IsIPv6 reports whether v is an IPv6 address, including IPv4-mapped IPv6 addresses.
+// It returns false for values that are not addresses.
+//
+// Example:
+//
+//	{{ net.IsIPv6 "2001:db8::1" }} // Output: true
+func (ctx Net) IsIPv6(v any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetIsIPv6]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.NetIsIPv6}
+	}
+	if fn, ok := override[func(any) (bool, error)](ctx.options, funcs.NetIsIPv6); ok {
+		return fn(v)
+	}
+	a, err := toAddr(v)
+	return err == nil && a.Is6(), nil
+}
+
+// SplitHostPort splits a network address of the form host:port or [host]:port into host and port.
+//
+// Example:
+//
+//	{{ ( net.SplitHostPort "[::1]:8080" ).Host }} // Output: ::1
+func (ctx Net) SplitHostPort(hostport string) (HostPortResult, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetSplitHostPort]; !ok {
+		return HostPortResult{}, &FuncNotAllowedError{Func: funcs.NetSplitHostPort}
+	}
+	if fn, ok := override[func(string) (HostPortResult, error)](ctx.options, funcs.NetSplitHostPort); ok {
+		return fn(hostport)
+	}
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return HostPortResult{}, err
+	}
+	return HostPortResult{
+		Host: host,
+		Port: port,
+	}, nil
+}
+
+// JoinHostPort combines host and port into a network address, IPv6 hosts are enclosed in brackets.
+//
+// Example:
+//
+//	{{ net.JoinHostPort "::1" 8080 }} // Output: [::1]:8080
+func (ctx Net) JoinHostPort(host, port any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.NetJoinHostPort]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.NetJoinHostPort}
+	}
+	if fn, ok := override[func(any, any) (string, error)](ctx.options, funcs.NetJoinHostPort); ok {
+		return fn(host, port)
+	}
+	return net.JoinHostPort(toString(host), toString(port)), nil
+}
@@ -0,0 +1,136 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleNet_Contains() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Contains "10.0.0.0/8" "10.1.2.3" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleNet_Contains_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Contains "10.0.0.0/16" "10.0.0.0/8" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
+func ExampleNet_Host() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Host "192.168.1.0/24" 10 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 192.168.1.10
+}
+
+func ExampleNet_Host_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Host "192.168.1.0/24" -2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 192.168.1.254
+}
+
+func ExampleNet_IsIPv4() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.IsIPv4 "10.0.0.1" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleNet_IsIPv4_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.IsIPv4 "::ffff:10.0.0.1" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
+func ExampleNet_IsIPv6() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.IsIPv6 "2001:db8::1" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleNet_JoinHostPort() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.JoinHostPort "::1" 8080 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [::1]:8080
+}
+
+func ExampleNet_Next() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Next "10.0.0.255" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 10.0.1.0
+}
+
+func ExampleNet_ParseAddr() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.ParseAddr "2001:0db8::0001" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2001:db8::1
+}
+
+func ExampleNet_ParsePrefix() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ ( net.ParsePrefix "10.1.2.3/8" ).Masked }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 10.0.0.0/8
+}
+
+func ExampleNet_Prev() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Prev "2001:db8::1:0" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2001:db8::ffff
+}
+
+func ExampleNet_SplitHostPort() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ ( net.SplitHostPort "[::1]:8080" ).Host }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: ::1
+}
+
+func ExampleNet_Subnet() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ net.Subnet "10.0.0.0/16" 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [10.0.0.0/18 10.0.64.0/18 10.0.128.0/18 10.0.192.0/18]
+}
+
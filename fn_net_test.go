@@ -0,0 +1,110 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestNet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{name: "parse addr with zone", tmpl: `{{ net.ParseAddr "fe80::1%eth0" }}`, want: "fe80::1%eth0"},
+		{name: "parse prefix", tmpl: `{{ ( net.ParsePrefix "2001:db8::1/32" ).Bits }}`, want: "32"},
+		{name: "contains addr", tmpl: `{{ net.Contains "192.168.0.0/16" "192.169.0.1" }}`, want: "false"},
+		{name: "contains prefix", tmpl: `{{ net.Contains "10.0.0.0/8" "10.20.0.0/16" }}`, want: "true"},
+		{
+			name: "contains parsed values",
+			tmpl: `{{ net.Contains ( net.ParsePrefix "::/0" ) ( net.ParseAddr "::1" ) }}`,
+			want: "true",
+		},
+		{name: "contains mixed families", tmpl: `{{ net.Contains "0.0.0.0/0" "::1" }}`, want: "false"},
+		{name: "host network address", tmpl: `{{ net.Host "10.1.2.3/16" 0 }}`, want: "10.1.0.0"},
+		{name: "host last address", tmpl: `{{ net.Host "10.1.0.0/16" -1 }}`, want: "10.1.255.255"},
+		{name: "host ipv6", tmpl: `{{ net.Host "2001:db8::/64" 65536 }}`, want: "2001:db8::1:0"},
+		{name: "host single address", tmpl: `{{ net.Host "10.0.0.1/32" 0 }}`, want: "10.0.0.1"},
+		{name: "subnet ipv6", tmpl: `{{ net.Subnet "2001:db8::/32" 1 }}`, want: "[2001:db8::/33 2001:db8:8000::/33]"},
+		{name: "subnet zero bits", tmpl: `{{ net.Subnet "10.0.0.0/8" 0 }}`, want: "[10.0.0.0/8]"},
+		{name: "subnet count", tmpl: `{{ len ( net.Subnet "10.0.0.0/8" 16 ) }}`, want: "65536"},
+		{
+			name: "subnet of subnet",
+			tmpl: `{{ net.Host ( index ( net.Subnet "10.0.0.0/16" 8 ) 3 ) 1 }}`,
+			want: "10.0.3.1",
+		},
+		{name: "next ipv6", tmpl: `{{ net.Next "::ffff" }}`, want: "::1:0"},
+		{name: "prev ipv4", tmpl: `{{ net.Prev "10.0.1.0" }}`, want: "10.0.0.255"},
+		{name: "ipv4 of invalid", tmpl: `{{ net.IsIPv4 "example.com" }}`, want: "false"},
+		{name: "ipv6 of mapped", tmpl: `{{ net.IsIPv6 "::ffff:10.0.0.1" }}`, want: "true"},
+		{name: "ipv6 of ipv4", tmpl: `{{ net.IsIPv6 "10.0.0.1" }}`, want: "false"},
+		{name: "split host port", tmpl: `{{ net.SplitHostPort "example.com:443" }}`, want: "{example.com 443}"},
+		{name: "join host port", tmpl: `{{ net.JoinHostPort "example.com" "https" }}`, want: "example.com:https"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Net)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNet_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr error
+	}{
+		{name: "host out of range", tmpl: `{{ net.Host "10.0.0.0/24" 256 }}`, wantErr: xtemplate.ErrAddrOutOfRange},
+		{
+			name:    "negative host out of range",
+			tmpl:    `{{ net.Host "10.0.0.0/24" -257 }}`,
+			wantErr: xtemplate.ErrAddrOutOfRange,
+		},
+		{name: "next overflow", tmpl: `{{ net.Next "255.255.255.255" }}`, wantErr: xtemplate.ErrAddrOutOfRange},
+		{name: "prev overflow", tmpl: `{{ net.Prev "::" }}`, wantErr: xtemplate.ErrAddrOutOfRange},
+		{name: "subnet too long", tmpl: `{{ net.Subnet "10.0.0.0/24" 9 }}`, wantErr: xtemplate.ErrInvalidPrefixLength},
+		{name: "subnet negative", tmpl: `{{ net.Subnet "10.0.0.0/24" -1 }}`, wantErr: xtemplate.ErrInvalidPrefixLength},
+		{
+			name:    "too many subnets",
+			tmpl:    `{{ net.Subnet "2001:db8::/32" 64 }}`,
+			wantErr: xtemplate.ErrInvalidPrefixLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Net)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	for _, tmpl := range []string{
+		`{{ net.ParseAddr "example.com" }}`,
+		`{{ net.Contains "10.0.0.0" "10.0.0.1" }}`,
+		`{{ net.SplitHostPort "example.com" }}`,
+	} {
+		if _, err := xtemplate.QuickExecute(tmpl, nil, funcs.Net); err == nil {
+			t.Errorf("QuickExecute(%q) expected an error", tmpl)
+		}
+	}
+}
@@ -20,6 +20,9 @@ func (ctx OS) Chdir(dir string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSChdir]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSChdir}
 	}
+	if fn, ok := override[func(string) error](ctx.options, funcs.OSChdir); ok {
+		return fn(dir)
+	}
 	return os.Chdir(dir)
 }
 
@@ -33,6 +36,9 @@ func (ctx OS) Chmod(name string, mode os.FileMode) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSChmod]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSChmod}
 	}
+	if fn, ok := override[func(string, os.FileMode) error](ctx.options, funcs.OSChmod); ok {
+		return fn(name, mode)
+	}
 	return os.Chmod(name, mode)
 }
 
@@ -46,6 +52,9 @@ func (ctx OS) Chown(name string, uid, gid int) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSChown]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSChown}
 	}
+	if fn, ok := override[func(string, int, int) error](ctx.options, funcs.OSChown); ok {
+		return fn(name, uid, gid)
+	}
 	return os.Chown(name, uid, gid)
 }
 
@@ -59,6 +68,9 @@ func (ctx OS) Chtimes(name string, atime time.Time, mtime time.Time) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSChtimes]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSChtimes}
 	}
+	if fn, ok := override[func(string, time.Time, time.Time) error](ctx.options, funcs.OSChtimes); ok {
+		return fn(name, atime, mtime)
+	}
 	return os.Chtimes(name, atime, mtime)
 }
 
@@ -71,6 +83,9 @@ func (ctx OS) Clearenv() error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSClearenv]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSClearenv}
 	}
+	if fn, ok := override[func() error](ctx.options, funcs.OSClearenv); ok {
+		return fn()
+	}
 	os.Clearenv()
 	return nil
 }
@@ -85,11 +100,15 @@ func (ctx OS) Environ() ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSEnviron]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.OSEnviron}
 	}
+	if fn, ok := override[func() ([]string, error)](ctx.options, funcs.OSEnviron); ok {
+		return fn()
+	}
 	return os.Environ(), nil
 }
 
 // Executable returns the path name for the executable that started
 // the current process.
+// In deterministic mode Identity.Executable is returned instead.
 //
 // Example:
 //
@@ -98,6 +117,15 @@ func (ctx OS) Executable() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSExecutable]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSExecutable}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSExecutable); ok {
+		return fn()
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.Executable == "" {
+			return "", &NondeterministicError{Func: funcs.OSExecutable}
+		}
+		return ctx.options.identity.Executable, nil
+	}
 	return os.Executable()
 }
 
@@ -111,6 +139,9 @@ func (ctx OS) Exit(code int) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSExit]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSExit}
 	}
+	if fn, ok := override[func(int) error](ctx.options, funcs.OSExit); ok {
+		return fn(code)
+	}
 	os.Exit(code)
 	return nil
 }
@@ -125,6 +156,9 @@ func (ctx OS) Expand(s string, mapping func(string) string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSExpand]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSExpand}
 	}
+	if fn, ok := override[func(string, func(string) string) (string, error)](ctx.options, funcs.OSExpand); ok {
+		return fn(s, mapping)
+	}
 	return os.Expand(s, mapping), nil
 }
 
@@ -138,6 +172,9 @@ func (ctx OS) ExpandEnv(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSExpandEnv]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSExpandEnv}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.OSExpandEnv); ok {
+		return fn(s)
+	}
 	return os.ExpandEnv(s), nil
 }
 
@@ -151,6 +188,9 @@ func (ctx OS) Getegid() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetegid]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGetegid}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGetegid); ok {
+		return fn()
+	}
 	return os.Getegid(), nil
 }
 
@@ -164,6 +204,9 @@ func (ctx OS) Getenv(key string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetenv]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSGetenv}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.OSGetenv); ok {
+		return fn(key)
+	}
 	return os.Getenv(key), nil
 }
 
@@ -177,6 +220,9 @@ func (ctx OS) Geteuid() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGeteuid]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGeteuid}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGeteuid); ok {
+		return fn()
+	}
 	return os.Geteuid(), nil
 }
 
@@ -190,6 +236,9 @@ func (ctx OS) Getgid() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetgid]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGetgid}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGetgid); ok {
+		return fn()
+	}
 	return os.Getgid(), nil
 }
 
@@ -202,6 +251,9 @@ func (ctx OS) Getgroups() ([]int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetgroups]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.OSGetgroups}
 	}
+	if fn, ok := override[func() ([]int, error)](ctx.options, funcs.OSGetgroups); ok {
+		return fn()
+	}
 	return os.Getgroups()
 }
 
@@ -214,10 +266,14 @@ func (ctx OS) Getpagesize() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetpagesize]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGetpagesize}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGetpagesize); ok {
+		return fn()
+	}
 	return os.Getpagesize(), nil
 }
 
 // Getpid returns the process id of the caller.
+// In deterministic mode Identity.Pid is returned instead.
 //
 // Example:
 //
@@ -226,10 +282,20 @@ func (ctx OS) Getpid() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetpid]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGetpid}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGetpid); ok {
+		return fn()
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.Pid == 0 {
+			return 0, &NondeterministicError{Func: funcs.OSGetpid}
+		}
+		return ctx.options.identity.Pid, nil
+	}
 	return os.Getpid(), nil
 }
 
 // Getppid returns the process id of the caller's parent.
+// In deterministic mode Identity.Ppid is returned instead.
 //
 // Example:
 //
@@ -238,6 +304,15 @@ func (ctx OS) Getppid() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetppid]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGetppid}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGetppid); ok {
+		return fn()
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.Ppid == 0 {
+			return 0, &NondeterministicError{Func: funcs.OSGetppid}
+		}
+		return ctx.options.identity.Ppid, nil
+	}
 	return os.Getppid(), nil
 }
 
@@ -251,11 +326,15 @@ func (ctx OS) Getuid() (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetuid]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.OSGetuid}
 	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.OSGetuid); ok {
+		return fn()
+	}
 	return os.Getuid(), nil
 }
 
 // Getwd returns a rooted path name corresponding to the
 // current directory.
+// In deterministic mode Identity.Wd is returned instead.
 //
 // Example:
 //
@@ -264,10 +343,20 @@ func (ctx OS) Getwd() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSGetwd]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSGetwd}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSGetwd); ok {
+		return fn()
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.Wd == "" {
+			return "", &NondeterministicError{Func: funcs.OSGetwd}
+		}
+		return ctx.options.identity.Wd, nil
+	}
 	return os.Getwd()
 }
 
 // Hostname returns the host name reported by the kernel.
+// In deterministic mode Identity.Hostname is returned instead.
 //
 // Example:
 //
@@ -276,6 +365,15 @@ func (ctx OS) Hostname() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSHostname]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSHostname}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSHostname); ok {
+		return fn()
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.Hostname == "" {
+			return "", &NondeterministicError{Func: funcs.OSHostname}
+		}
+		return ctx.options.identity.Hostname, nil
+	}
 	return os.Hostname()
 }
 
@@ -289,6 +387,9 @@ func (ctx OS) IsExist(err error) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSIsExist]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.OSIsExist}
 	}
+	if fn, ok := override[func(error) (bool, error)](ctx.options, funcs.OSIsExist); ok {
+		return fn(err)
+	}
 	return os.IsExist(err), nil
 }
 
@@ -302,6 +403,9 @@ func (ctx OS) IsNotExist(err error) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSIsNotExist]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.OSIsNotExist}
 	}
+	if fn, ok := override[func(error) (bool, error)](ctx.options, funcs.OSIsNotExist); ok {
+		return fn(err)
+	}
 	return os.IsNotExist(err), nil
 }
 
@@ -314,6 +418,9 @@ func (ctx OS) IsPathSeparator(c uint8) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSIsPathSeparator]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.OSIsPathSeparator}
 	}
+	if fn, ok := override[func(uint8) (bool, error)](ctx.options, funcs.OSIsPathSeparator); ok {
+		return fn(c)
+	}
 	return os.IsPathSeparator(c), nil
 }
 
@@ -327,6 +434,9 @@ func (ctx OS) IsPermission(err error) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSIsPermission]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.OSIsPermission}
 	}
+	if fn, ok := override[func(error) (bool, error)](ctx.options, funcs.OSIsPermission); ok {
+		return fn(err)
+	}
 	return os.IsPermission(err), nil
 }
 
@@ -340,6 +450,9 @@ func (ctx OS) IsTimeout(err error) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSIsTimeout]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.OSIsTimeout}
 	}
+	if fn, ok := override[func(error) (bool, error)](ctx.options, funcs.OSIsTimeout); ok {
+		return fn(err)
+	}
 	return os.IsTimeout(err), nil
 }
 
@@ -353,6 +466,9 @@ func (ctx OS) Lchown(name string, uid, gid int) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSLchown]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSLchown}
 	}
+	if fn, ok := override[func(string, int, int) error](ctx.options, funcs.OSLchown); ok {
+		return fn(name, uid, gid)
+	}
 	return os.Lchown(name, uid, gid)
 }
 
@@ -365,6 +481,9 @@ func (ctx OS) Link(oldname, newname string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSLink]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSLink}
 	}
+	if fn, ok := override[func(string, string) error](ctx.options, funcs.OSLink); ok {
+		return fn(oldname, newname)
+	}
 	return os.Link(oldname, newname)
 }
 
@@ -380,6 +499,9 @@ func (ctx OS) LookupEnv(key string) (string, bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSLookupEnv]; !ok {
 		return "", false, &FuncNotAllowedError{Func: funcs.OSLookupEnv}
 	}
+	if fn, ok := override[func(string) (string, bool, error)](ctx.options, funcs.OSLookupEnv); ok {
+		return fn(key)
+	}
 	value, found := os.LookupEnv(key)
 	return value, found, nil
 }
@@ -394,6 +516,9 @@ func (ctx OS) Mkdir(name string, perm os.FileMode) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSMkdir]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSMkdir}
 	}
+	if fn, ok := override[func(string, os.FileMode) error](ctx.options, funcs.OSMkdir); ok {
+		return fn(name, perm)
+	}
 	return os.Mkdir(name, perm)
 }
 
@@ -407,11 +532,15 @@ func (ctx OS) MkdirAll(path string, perm os.FileMode) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSMkdirAll]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSMkdirAll}
 	}
+	if fn, ok := override[func(string, os.FileMode) error](ctx.options, funcs.OSMkdirAll); ok {
+		return fn(path, perm)
+	}
 	return os.MkdirAll(path, perm)
 }
 
 // MkdirTemp creates a new temporary directory in the directory dir
 // and returns the pathname of the new directory.
+// In deterministic mode Identity.MkdirTemp is called instead.
 //
 // Example:
 //
@@ -420,6 +549,15 @@ func (ctx OS) MkdirTemp(dir, pattern string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSMkdirTemp]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSMkdirTemp}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.OSMkdirTemp); ok {
+		return fn(dir, pattern)
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.MkdirTemp == nil {
+			return "", &NondeterministicError{Func: funcs.OSMkdirTemp}
+		}
+		return ctx.options.identity.MkdirTemp(dir, pattern)
+	}
 	return os.MkdirTemp(dir, pattern)
 }
 
@@ -433,6 +571,9 @@ func (ctx OS) NewSyscallError(syscall string, err error) (error, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSNewSyscallError]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.OSNewSyscallError}
 	}
+	if fn, ok := override[func(string, error) (error, error)](ctx.options, funcs.OSNewSyscallError); ok {
+		return fn(syscall, err)
+	}
 	return os.NewSyscallError(syscall, err), nil
 }
 
@@ -445,6 +586,9 @@ func (ctx OS) Pipe() (*os.File, *os.File, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSPipe]; !ok {
 		return nil, nil, &FuncNotAllowedError{Func: funcs.OSPipe}
 	}
+	if fn, ok := override[func() (*os.File, *os.File, error)](ctx.options, funcs.OSPipe); ok {
+		return fn()
+	}
 	return os.Pipe()
 }
 
@@ -457,6 +601,9 @@ func (ctx OS) ReadFile(name string) ([]byte, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSReadFile]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.OSReadFile}
 	}
+	if fn, ok := override[func(string) ([]byte, error)](ctx.options, funcs.OSReadFile); ok {
+		return fn(name)
+	}
 	return os.ReadFile(name) //nolint:gosec // G304: allowed function
 }
 
@@ -469,6 +616,9 @@ func (ctx OS) Readlink(name string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSReadlink]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSReadlink}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.OSReadlink); ok {
+		return fn(name)
+	}
 	return os.Readlink(name)
 }
 
@@ -481,6 +631,9 @@ func (ctx OS) Remove(name string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSRemove]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSRemove}
 	}
+	if fn, ok := override[func(string) error](ctx.options, funcs.OSRemove); ok {
+		return fn(name)
+	}
 	return os.Remove(name)
 }
 
@@ -494,6 +647,9 @@ func (ctx OS) RemoveAll(path string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSRemoveAll]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSRemoveAll}
 	}
+	if fn, ok := override[func(string) error](ctx.options, funcs.OSRemoveAll); ok {
+		return fn(path)
+	}
 	return os.RemoveAll(path)
 }
 
@@ -507,6 +663,9 @@ func (ctx OS) Rename(oldpath, newpath string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSRename]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSRename}
 	}
+	if fn, ok := override[func(string, string) error](ctx.options, funcs.OSRename); ok {
+		return fn(oldpath, newpath)
+	}
 	return os.Rename(oldpath, newpath)
 }
 
@@ -519,6 +678,9 @@ func (ctx OS) SameFile(fi1, fi2 os.FileInfo) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSSameFile]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.OSSameFile}
 	}
+	if fn, ok := override[func(os.FileInfo, os.FileInfo) (bool, error)](ctx.options, funcs.OSSameFile); ok {
+		return fn(fi1, fi2)
+	}
 	return os.SameFile(fi1, fi2), nil
 }
 
@@ -531,6 +693,9 @@ func (ctx OS) Setenv(key, value string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSSetenv]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSSetenv}
 	}
+	if fn, ok := override[func(string, string) error](ctx.options, funcs.OSSetenv); ok {
+		return fn(key, value)
+	}
 	return os.Setenv(key, value)
 }
 
@@ -543,10 +708,14 @@ func (ctx OS) Symlink(oldname, newname string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSSymlink]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSSymlink}
 	}
+	if fn, ok := override[func(string, string) error](ctx.options, funcs.OSSymlink); ok {
+		return fn(oldname, newname)
+	}
 	return os.Symlink(oldname, newname)
 }
 
 // TempDir returns the default directory to use for temporary files.
+// In deterministic mode Identity.TempDir is returned instead.
 //
 // Example:
 //
@@ -555,6 +724,15 @@ func (ctx OS) TempDir() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSTempDir]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSTempDir}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSTempDir); ok {
+		return fn()
+	}
+	if ctx.options.deterministic {
+		if ctx.options.identity.TempDir == "" {
+			return "", &NondeterministicError{Func: funcs.OSTempDir}
+		}
+		return ctx.options.identity.TempDir, nil
+	}
 	return os.TempDir(), nil
 }
 
@@ -567,6 +745,9 @@ func (ctx OS) Truncate(name string, size int64) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSTruncate]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSTruncate}
 	}
+	if fn, ok := override[func(string, int64) error](ctx.options, funcs.OSTruncate); ok {
+		return fn(name, size)
+	}
 	return os.Truncate(name, size)
 }
 
@@ -579,6 +760,9 @@ func (ctx OS) Unsetenv(key string) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSUnsetenv]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSUnsetenv}
 	}
+	if fn, ok := override[func(string) error](ctx.options, funcs.OSUnsetenv); ok {
+		return fn(key)
+	}
 	return os.Unsetenv(key)
 }
 
@@ -591,6 +775,9 @@ func (ctx OS) UserCacheDir() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSUserCacheDir]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSUserCacheDir}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSUserCacheDir); ok {
+		return fn()
+	}
 	return os.UserCacheDir()
 }
 
@@ -603,6 +790,9 @@ func (ctx OS) UserConfigDir() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSUserConfigDir]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSUserConfigDir}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSUserConfigDir); ok {
+		return fn()
+	}
 	return os.UserConfigDir()
 }
 
@@ -615,6 +805,9 @@ func (ctx OS) UserHomeDir() (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSUserHomeDir]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.OSUserHomeDir}
 	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.OSUserHomeDir); ok {
+		return fn()
+	}
 	return os.UserHomeDir()
 }
 
@@ -627,5 +820,8 @@ func (ctx OS) WriteFile(name string, data []byte, perm os.FileMode) error {
 	if _, ok := ctx.allowedFunctionSet[funcs.OSWriteFile]; !ok {
 		return &FuncNotAllowedError{Func: funcs.OSWriteFile}
 	}
+	if fn, ok := override[func(string, []byte, os.FileMode) error](ctx.options, funcs.OSWriteFile); ok {
+		return fn(name, data, perm)
+	}
 	return os.WriteFile(name, data, perm)
 }
@@ -20,6 +20,9 @@ func (ctx Path) Dir(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.PathDir]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.PathDir}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.PathDir); ok {
+		return fn(s)
+	}
 	return path.Dir(s), nil
 }
 
@@ -33,6 +36,9 @@ func (ctx Path) Base(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.PathBase]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.PathBase}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.PathBase); ok {
+		return fn(s)
+	}
 	return path.Base(s), nil
 }
 
@@ -46,6 +52,9 @@ func (ctx Path) Join(s ...string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.PathJoin]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.PathJoin}
 	}
+	if fn, ok := override[func(...string) (string, error)](ctx.options, funcs.PathJoin); ok {
+		return fn(s...)
+	}
 	return path.Join(s...), nil
 }
 
@@ -59,6 +68,9 @@ func (ctx Path) Clean(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.PathClean]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.PathClean}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.PathClean); ok {
+		return fn(s)
+	}
 	return path.Clean(s), nil
 }
 
@@ -72,5 +84,62 @@ func (ctx Path) Ext(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.PathExt]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.PathExt}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.PathExt); ok {
+		return fn(s)
+	}
 	return path.Ext(s), nil
 }
+
+// PathSplitResult holds the result of Split.
+type PathSplitResult struct {
+	Dir  string
+	File string
+}
+
+// Match reports whether name matches the shell pattern.
+// The pattern syntax is the one of path.Match, e.g. "*" matches any sequence of non-separator characters.
+//
+// Example:
+//
+//	{{ path.Match "src/*.go" "src/main.go" }} // Output: true
+func (ctx Path) Match(pattern, name string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.PathMatch]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.PathMatch}
+	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.PathMatch); ok {
+		return fn(pattern, name)
+	}
+	return path.Match(pattern, name)
+}
+
+// Split splits path immediately following the final slash, separating it into a directory and file name component.
+// If there is no slash in path, Split returns an empty dir and file set to path.
+//
+// Example:
+//
+//	{{ (path.Split "static/css/site.css").Dir }} // Output: static/css/
+func (ctx Path) Split(s string) (PathSplitResult, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.PathSplit]; !ok {
+		return PathSplitResult{}, &FuncNotAllowedError{Func: funcs.PathSplit}
+	}
+	if fn, ok := override[func(string) (PathSplitResult, error)](ctx.options, funcs.PathSplit); ok {
+		return fn(s)
+	}
+	dir, file := path.Split(s)
+	return PathSplitResult{Dir: dir, File: file}, nil
+}
+
+// IsAbs reports whether the path is absolute.
+//
+// Example:
+//
+//	{{ path.IsAbs "/dev/null" }} // Output: true
+func (ctx Path) IsAbs(s string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.PathIsAbs]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.PathIsAbs}
+	}
+	if fn, ok := override[func(string) (bool, error)](ctx.options, funcs.PathIsAbs); ok {
+		return fn(s)
+	}
+	return path.IsAbs(s), nil
+}
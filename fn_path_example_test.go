@@ -44,6 +44,15 @@ func ExamplePath_Ext() {
 	fmt.Println(s) // Output: .js
 }
 
+func ExamplePath_IsAbs() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ path.IsAbs "/dev/null" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
 func ExamplePath_Join() {
 	s, _ := xtemplate.QuickExecute(
 		`{{ path.Join "foo" "bar" "baz" }}`,
@@ -53,3 +62,21 @@ func ExamplePath_Join() {
 	fmt.Println(s) // Output: foo/bar/baz
 }
 
+func ExamplePath_Match() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ path.Match "src/*.go" "src/main.go" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExamplePath_Split() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ (path.Split "static/css/site.css").Dir }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: static/css/
+}
+
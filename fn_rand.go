@@ -0,0 +1,266 @@
+package xtemplate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Rand provides pseudo-random numbers and selections, e.g. for test fixtures and sample data.
+//
+// The functions use a math/rand/v2 source that can be seeded with WithRandSeed to get repeatable results.
+// The source belongs to the FuncMap, see WithRandSeed for when it restarts.
+// The values are not suitable for secrets, use CryptoString for those.
+type Rand rootContext
+
+// ErrEmptySlice is returned when an element is requested from an empty slice.
+var ErrEmptySlice = errors.New("slice is empty")
+
+// ErrInvalidArgument is returned when a numeric argument is out of range, e.g. a negative length.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrEmptyAlphabet is returned when a random string is requested from an empty alphabet.
+var ErrEmptyAlphabet = errors.New("alphabet is empty")
+
+// maxRandStringLength limits the length of random strings to keep the size of the results reasonable.
+const maxRandStringLength = 1 << 16
+
+func checkRandStringLength(n int) error {
+	if n < 0 || n > maxRandStringLength {
+		return fmt.Errorf("%w: length %d", ErrInvalidArgument, n)
+	}
+	return nil
+}
+
+// sliceToAny copies the elements of the slice s to a []any.
+func sliceToAny(s any) ([]any, error) {
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, ErrArgNotSlice
+	}
+	out := make([]any, val.Len())
+	for i := range out {
+		out[i] = val.Index(i).Interface()
+	}
+	return out, nil
+}
+
+// Int returns a non-negative pseudo-random int.
+//
+// Example:
+//
+//	{{ lt rand.Int 0 }} // Output: false
+func (ctx Rand) Int() (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandInt]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.RandInt}
+	}
+	if fn, ok := override[func() (int, error)](ctx.options, funcs.RandInt); ok {
+		return fn()
+	}
+	var n int
+	err := ctx.options.withRand(funcs.RandInt, func(r *rand.Rand) {
+		n = r.Int()
+	})
+	return n, err
+}
+
+// IntN returns a pseudo-random int in the half-open interval [0,n).
+//
+// Example:
+//
+//	{{ lt ( rand.IntN 10 ) 10 }} // Output: true
+func (ctx Rand) IntN(n int) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandIntN]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.RandIntN}
+	}
+	if fn, ok := override[func(int) (int, error)](ctx.options, funcs.RandIntN); ok {
+		return fn(n)
+	}
+	if n <= 0 {
+		return 0, ErrInvalidArgument
+	}
+	var v int
+	err := ctx.options.withRand(funcs.RandIntN, func(r *rand.Rand) {
+		v = r.IntN(n)
+	})
+	return v, err
+}
+
+// Float returns a pseudo-random float64 in the half-open interval [0.0,1.0).
+//
+// Example:
+//
+//	{{ lt rand.Float 1.0 }} // Output: true
+func (ctx Rand) Float() (float64, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandFloat]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.RandFloat}
+	}
+	if fn, ok := override[func() (float64, error)](ctx.options, funcs.RandFloat); ok {
+		return fn()
+	}
+	var f float64
+	err := ctx.options.withRand(funcs.RandFloat, func(r *rand.Rand) {
+		f = r.Float64()
+	})
+	return f, err
+}
+
+// Shuffle returns a copy of the slice s with its elements in pseudo-random order.
+//
+// Example:
+//
+//	{{ len ( rand.Shuffle ( slice.New 1 2 3 ) ) }} // Output: 3
+func (ctx Rand) Shuffle(s any) ([]any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandShuffle]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.RandShuffle}
+	}
+	if fn, ok := override[func(any) ([]any, error)](ctx.options, funcs.RandShuffle); ok {
+		return fn(s)
+	}
+	sl, err := sliceToAny(s)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.options.withRand(funcs.RandShuffle, func(r *rand.Rand) {
+		r.Shuffle(len(sl), func(i, j int) {
+			sl[i], sl[j] = sl[j], sl[i]
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// Choice returns a pseudo-randomly chosen element of the slice s.
+//
+// Example:
+//
+//	{{ rand.Choice ( slice.New "Hello" "Hello" ) }} // Output: Hello
+func (ctx Rand) Choice(s any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandChoice]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.RandChoice}
+	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.RandChoice); ok {
+		return fn(s)
+	}
+	sl, err := sliceToAny(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(sl) == 0 {
+		return nil, ErrEmptySlice
+	}
+	var v any
+	err = ctx.options.withRand(funcs.RandChoice, func(r *rand.Rand) {
+		v = sl[r.IntN(len(sl))]
+	})
+	return v, err
+}
+
+// Sample returns k distinct elements of the slice s, chosen pseudo-randomly.
+//
+// Example:
+//
+//	{{ len ( rand.Sample ( slice.New 1 2 3 ) 2 ) }} // Output: 2
+func (ctx Rand) Sample(s any, k int) ([]any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandSample]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.RandSample}
+	}
+	if fn, ok := override[func(any, int) ([]any, error)](ctx.options, funcs.RandSample); ok {
+		return fn(s, k)
+	}
+	sl, err := sliceToAny(s)
+	if err != nil {
+		return nil, err
+	}
+	if k < 0 || k > len(sl) {
+		return nil, ErrInvalidArgument
+	}
+	err = ctx.options.withRand(funcs.RandSample, func(r *rand.Rand) {
+		// partial Fisher-Yates shuffle
+		for i := range k {
+			j := i + r.IntN(len(sl)-i)
+			sl[i], sl[j] = sl[j], sl[i]
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clip(sl[:k]), nil
+}
+
+// String returns a pseudo-random string of n characters from the given alphabet, n is limited to 65536.
+// Use CryptoString for secrets.
+//
+// Example:
+//
+//	{{ len ( rand.String 8 "abcdef0123456789" ) }} // Output: 8
+func (ctx Rand) String(n int, alphabet string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandString]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.RandString}
+	}
+	if fn, ok := override[func(int, string) (string, error)](ctx.options, funcs.RandString); ok {
+		return fn(n, alphabet)
+	}
+	chars := []rune(alphabet)
+	if len(chars) == 0 {
+		return "", ErrEmptyAlphabet
+	}
+	if err := checkRandStringLength(n); err != nil {
+		return "", err
+	}
+	out := make([]rune, n)
+	err := ctx.options.withRand(funcs.RandString, func(r *rand.Rand) {
+		for i := range out {
+			out[i] = chars[r.IntN(len(chars))]
+		}
+	})
+	return string(out), err
+}
+
+// CryptoString returns a cryptographically secure random string of n characters from the given alphabet,
+// e.g. for passwords and tokens. The random bytes are read from crypto/rand, or the source set with WithEntropy.
+// n is limited to 65536.
+//
+// Example:
+//
+//	{{ len ( rand.CryptoString 32 "abcdefghijklmnopqrstuvwxyz0123456789" ) }} // Output: 32
+func (ctx Rand) CryptoString(n int, alphabet string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.RandCryptoString]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.RandCryptoString}
+	}
+	if fn, ok := override[func(int, string) (string, error)](ctx.options, funcs.RandCryptoString); ok {
+		return fn(n, alphabet)
+	}
+	chars := []rune(alphabet)
+	if len(chars) == 0 {
+		return "", ErrEmptyAlphabet
+	}
+	if err := checkRandStringLength(n); err != nil {
+		return "", err
+	}
+	// reject values above the largest multiple of len(chars) to avoid a modulo bias
+	size := uint64(len(chars))
+	limit := math.MaxUint64 - math.MaxUint64%size
+	out := make([]rune, n)
+	var buf [8]byte
+	for i := range out {
+		for {
+			if err := ctx.options.readRandom(funcs.RandCryptoString, buf[:]); err != nil {
+				return "", err
+			}
+			if v := binary.LittleEndian.Uint64(buf[:]); v < limit {
+				out[i] = chars[v%size]
+				break
+			}
+		}
+	}
+	return string(out), nil
+}
@@ -0,0 +1,82 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleRand_Choice() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ rand.Choice ( slice.New "Hello" "Hello" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello
+}
+
+func ExampleRand_CryptoString() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len ( rand.CryptoString 32 "abcdefghijklmnopqrstuvwxyz0123456789" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 32
+}
+
+func ExampleRand_Float() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ lt rand.Float 1.0 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleRand_Int() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ lt rand.Int 0 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
+func ExampleRand_IntN() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ lt ( rand.IntN 10 ) 10 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleRand_Sample() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len ( rand.Sample ( slice.New 1 2 3 ) 2 ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2
+}
+
+func ExampleRand_Shuffle() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len ( rand.Shuffle ( slice.New 1 2 3 ) ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3
+}
+
+func ExampleRand_String() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len ( rand.String 8 "abcdef0123456789" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 8
+}
+
@@ -0,0 +1,193 @@
+package xtemplate_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestRand_Seed(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `{{ rand.Int }} {{ rand.IntN 100 }} {{ rand.Float }} {{ rand.Shuffle ( slice.New 1 2 3 4 5 ) }} ` +
+		`{{ rand.Choice ( slice.NewStrings "a" "b" "c" ) }} {{ rand.Sample ( slice.New 1 2 3 4 5 ) 3 }} ` +
+		`{{ rand.String 16 "abcdef" }}`
+
+	execute := func(t *testing.T, engine *xtemplate.Engine) string {
+		t.Helper()
+		tpl, err := engine.Bind(template.New("")).Parse(tmpl)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if err := engine.Execute(tpl, &buf, nil); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	engine := xtemplate.New(funcs.Safe, xtemplate.WithRandSeed(1, 2))
+	first := execute(t, engine)
+	if second := execute(t, engine); first != second {
+		t.Errorf("executions differ: %q != %q", first, second)
+	}
+	if other := execute(t, xtemplate.New(funcs.Safe, xtemplate.WithRandSeed(3, 4))); first == other {
+		t.Errorf("different seeds produced the same output %q", first)
+	}
+}
+
+func TestRand_SeedFuncMap(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `{{ rand.Int }} {{ rand.String 16 "abcdef" }}`
+
+	parse := func(t *testing.T) *template.Template {
+		t.Helper()
+		tpl := template.New("")
+		tpl, err := tpl.Funcs(xtemplate.FuncMap(tpl, funcs.Rand, xtemplate.WithRandSeed(1, 2))).Parse(tmpl)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		return tpl
+	}
+	execute := func(t *testing.T, tpl *template.Template) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := xtemplate.Execute(tpl, &buf, nil); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	tpl := parse(t)
+	first := execute(t, tpl)
+	if second := execute(t, tpl); first == second {
+		t.Errorf("second execution with the same FuncMap restarted the sequence: %q", second)
+	}
+	if fresh := execute(t, parse(t)); first != fresh {
+		t.Errorf("new FuncMap did not restart the sequence: %q != %q", first, fresh)
+	}
+
+	engine := xtemplate.New(funcs.Rand, xtemplate.WithRandSeed(1, 2))
+	var buf bytes.Buffer
+	for range 2 {
+		buf.Reset()
+		if err := engine.Execute(tpl, &buf, nil); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if buf.String() != first {
+			t.Errorf("Engine.Execute() = %q, want %q", buf.String(), first)
+		}
+	}
+}
+
+func TestRand_CryptoString(t *testing.T) {
+	t.Parallel()
+
+	got, err := xtemplate.QuickExecute(`{{ rand.CryptoString 4 "ab" }}`, nil,
+		funcs.RandCryptoString,
+		xtemplate.WithEntropy(strings.NewReader(strings.Repeat("\x00\x00\x00\x00\x00\x00\x00\x00", 2)+
+			strings.Repeat("\x01\x00\x00\x00\x00\x00\x00\x00", 2))),
+	)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "aabb"; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+}
+
+func TestRand_StringMaxLength(t *testing.T) {
+	t.Parallel()
+
+	got, err := xtemplate.QuickExecute(`{{ len ( rand.String 65536 "ab" ) }}`, nil, funcs.Safe)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if got != "65536" {
+		t.Errorf("QuickExecute() = %q, want %q", got, "65536")
+	}
+}
+
+func TestRand_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		options []xtemplate.AllowedFunctions
+		wantErr error
+	}{
+		{
+			name:    "invalid n",
+			tmpl:    `{{ rand.IntN 0 }}`,
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{
+			name:    "empty slice",
+			tmpl:    `{{ rand.Choice ( slice.New ) }}`,
+			wantErr: xtemplate.ErrEmptySlice,
+		},
+		{
+			name:    "sample too large",
+			tmpl:    `{{ rand.Sample ( slice.New 1 2 ) 3 }}`,
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{
+			name:    "not a slice",
+			tmpl:    `{{ rand.Shuffle "abc" }}`,
+			wantErr: xtemplate.ErrArgNotSlice,
+		},
+		{
+			name:    "empty alphabet",
+			tmpl:    `{{ rand.String 8 "" }}`,
+			wantErr: xtemplate.ErrEmptyAlphabet,
+		},
+		{
+			name:    "string too long",
+			tmpl:    `{{ rand.String 65537 "ab" }}`,
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{
+			name:    "negative string length",
+			tmpl:    `{{ rand.String -1 "ab" }}`,
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{
+			name:    "crypto string too long",
+			tmpl:    `{{ rand.CryptoString 65537 "ab" }}`,
+			options: []xtemplate.AllowedFunctions{funcs.RandCryptoString},
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{
+			name:    "deterministic without seed",
+			tmpl:    `{{ rand.Int }}`,
+			options: []xtemplate.AllowedFunctions{xtemplate.WithDeterministic(xtemplate.Identity{})},
+			wantErr: &xtemplate.NondeterministicError{Func: funcs.RandInt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, append(tt.options, funcs.Safe)...)
+			var nondeterministicErr *xtemplate.NondeterministicError
+			if errors.As(tt.wantErr, &nondeterministicErr) {
+				var gotErr *xtemplate.NondeterministicError
+				if !errors.As(err, &gotErr) || *gotErr != *nondeterministicErr {
+					t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -22,6 +22,9 @@ func (ctx Regexp) MatchString(pattern string, s string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpMatchString]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.RegexpMatchString}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.RegexpMatchString); ok {
+		return fn(pattern, s)
+	}
 	return regexp.MatchString(pattern, s)
 }
 
@@ -36,6 +39,9 @@ func (ctx Regexp) QuoteMeta(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpQuoteMeta]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.RegexpQuoteMeta}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.RegexpQuoteMeta); ok {
+		return fn(s)
+	}
 	return regexp.QuoteMeta(s), nil
 }
 
@@ -50,6 +56,9 @@ func (ctx Regexp) FindAllString(pattern string, s string, n int) ([]string, erro
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindAllString]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindAllString}
 	}
+	if fn, ok := override[func(string, string, int) ([]string, error)](ctx.options, funcs.RegexpFindAllString); ok {
+		return fn(pattern, s, n)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -65,8 +74,11 @@ func (ctx Regexp) FindAllString(pattern string, s string, n int) ([]string, erro
 //
 //	{{ regexp.FindAllStringIndex "p([a-z]+)ch" "peach punch" -1 }} // Output: [[0 5] [6 11]]
 func (ctx Regexp) FindAllStringIndex(pattern string, s string, n int) ([][]int, error) {
-	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindAllString]; !ok {
-		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindAllString}
+	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindAllStringIndex]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindAllStringIndex}
+	}
+	if fn, ok := override[func(string, string, int) ([][]int, error)](ctx.options, funcs.RegexpFindAllStringIndex); ok {
+		return fn(pattern, s, n)
 	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
@@ -98,6 +110,12 @@ func (ctx Regexp) FindAllStringSubmatch(pattern string, s string, n int) ([][]st
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindAllStringSubmatch]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindAllStringSubmatch}
 	}
+	if fn, ok := override[func(string, string, int) ([][]string, error)](
+		ctx.options,
+		funcs.RegexpFindAllStringSubmatch,
+	); ok {
+		return fn(pattern, s, n)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -132,6 +150,12 @@ func (ctx Regexp) FindAllStringSubmatchIndex(pattern string, s string, n int) ([
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindAllStringSubmatchIndex]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindAllStringSubmatchIndex}
 	}
+	if fn, ok := override[func(string, string, int) ([][]int, error)](
+		ctx.options,
+		funcs.RegexpFindAllStringSubmatchIndex,
+	); ok {
+		return fn(pattern, s, n)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -149,6 +173,9 @@ func (ctx Regexp) FindString(pattern string, s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindString]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.RegexpFindString}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.RegexpFindString); ok {
+		return fn(pattern, s)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return "", err
@@ -167,6 +194,9 @@ func (ctx Regexp) FindStringIndex(pattern string, s string) ([]int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindStringIndex]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindStringIndex}
 	}
+	if fn, ok := override[func(string, string) ([]int, error)](ctx.options, funcs.RegexpFindStringIndex); ok {
+		return fn(pattern, s)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -189,6 +219,9 @@ func (ctx Regexp) FindStringSubmatch(pattern string, s string) ([]string, error)
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindStringSubmatch]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindStringSubmatch}
 	}
+	if fn, ok := override[func(string, string) ([]string, error)](ctx.options, funcs.RegexpFindStringSubmatch); ok {
+		return fn(pattern, s)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -207,6 +240,9 @@ func (ctx Regexp) FindStringSubmatchIndex(pattern string, s string) ([]int, erro
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpFindStringSubmatchIndex]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpFindStringSubmatchIndex}
 	}
+	if fn, ok := override[func(string, string) ([]int, error)](ctx.options, funcs.RegexpFindStringSubmatchIndex); ok {
+		return fn(pattern, s)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -225,6 +261,12 @@ func (ctx Regexp) ReplaceAllLiteralString(pattern string, s string, repl string)
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpReplaceAllLiteralString]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.RegexpReplaceAllLiteralString}
 	}
+	if fn, ok := override[func(string, string, string) (string, error)](
+		ctx.options,
+		funcs.RegexpReplaceAllLiteralString,
+	); ok {
+		return fn(pattern, s, repl)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return "", err
@@ -243,6 +285,9 @@ func (ctx Regexp) ReplaceAllString(pattern string, s string, repl string) (strin
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpReplaceAllString]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.RegexpReplaceAllString}
 	}
+	if fn, ok := override[func(string, string, string) (string, error)](ctx.options, funcs.RegexpReplaceAllString); ok {
+		return fn(pattern, s, repl)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return "", err
@@ -274,6 +319,9 @@ func (ctx Regexp) Split(pattern string, s string, n int) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.RegexpSplit]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.RegexpSplit}
 	}
+	if fn, ok := override[func(string, string, int) ([]string, error)](ctx.options, funcs.RegexpSplit); ok {
+		return fn(pattern, s, n)
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,518 @@
+package xtemplate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Semver provides functions to parse, compare and bump semantic versions (https://semver.org).
+//
+// The functions accept SemverValue values and version strings, a leading "v" (e.g. "v1.2.3") is allowed.
+// A slice of SemverValue values can be sorted with slice.Sort.
+// Bumping a version part that is already the largest uint64 returns an OverflowError.
+//
+// Constraints consist of comparisons that all must match, separated by commas or spaces,
+// alternatives are separated by ||, e.g. ">=1.2.0, <2.0.0 || ^3.1".
+//
+// Operators:
+//   - "=" (or none), "!=", ">", ">=", "<", "<=": compare with the version
+//   - "~1.2.3": patch updates, >=1.2.3 <1.3.0
+//   - "^1.2.3": updates that do not change the leftmost non-zero part, >=1.2.3 <2.0.0 and ^0.2.3 is >=0.2.3 <0.3.0
+//
+// Versions can be partial or contain wildcards, "1.2", "1.2.x" and "1.2.*" all match >=1.2.0 <1.3.0.
+// Pre-release versions only match a constraint that contains a pre-release version with the same
+// major, minor and patch version, e.g. 1.2.3-beta.2 matches >=1.2.3-beta.1 but not >=1.2.0.
+type Semver rootContext
+
+// ErrInvalidSemver is returned when a value is not a valid semantic version.
+var ErrInvalidSemver = errors.New("invalid semantic version")
+
+// InvalidConstraintError is returned when a constraint cannot be parsed.
+type InvalidConstraintError struct {
+	Constraint string
+}
+
+func (e *InvalidConstraintError) Error() string {
+	return fmt.Sprintf("invalid version constraint %q", e.Constraint)
+}
+
+// SemverValue is a semantic version.
+type SemverValue struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string // dot separated identifiers without the leading "-"
+	Build      string // dot separated identifiers without the leading "+"
+}
+
+// String returns the version in its canonical form without a leading "v", e.g. "1.2.3-rc.1+build.5".
+func (v SemverValue) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler, versions are encoded as strings.
+func (v SemverValue) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower, equal or higher than o.
+// Build metadata is ignored.
+func (v SemverValue) Compare(o SemverValue) int {
+	if c := cmp.Compare(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := range min(len(a), len(b)) {
+		if c := comparePrereleaseIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// comparePrereleaseIdentifier compares numeric identifiers numerically, they have lower precedence than
+// alphanumeric identifiers which are compared in ASCII sort order.
+func comparePrereleaseIdentifier(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
+
+//nolint:gochecknoglobals // lookup table
+var semverRegexp = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+func parseSemver(s string) (SemverValue, error) {
+	m := semverRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return SemverValue{}, fmt.Errorf("%w: %q", ErrInvalidSemver, s)
+	}
+	var parts [3]uint64
+	for i := range parts {
+		var err error
+		if parts[i], err = strconv.ParseUint(m[i+1], 10, 64); err != nil {
+			return SemverValue{}, fmt.Errorf("%w: %q", ErrInvalidSemver, s)
+		}
+	}
+	return SemverValue{Major: parts[0], Minor: parts[1], Patch: parts[2], Prerelease: m[4], Build: m[5]}, nil
+}
+
+func toSemver(v any) (SemverValue, error) {
+	switch tv := v.(type) {
+	case SemverValue:
+		return tv, nil
+	case *SemverValue:
+		if tv != nil {
+			return *tv, nil
+		}
+	}
+	return parseSemver(toString(v))
+}
+
+// Parse parses s as a semantic version.
+//
+// Example 1:
+//
+//	{{ semver.Parse "v1.2.3-rc.1+build.5" }} // Output: 1.2.3-rc.1+build.5
+//
+// Example 2:
+//
+//	{{ ( semver.Parse "1.2.3-rc.1" ).Prerelease }} // Output: rc.1
+func (ctx Semver) Parse(s string) (SemverValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverParse]; !ok {
+		return SemverValue{}, &FuncNotAllowedError{Func: funcs.SemverParse}
+	}
+	if fn, ok := override[func(string) (SemverValue, error)](ctx.options, funcs.SemverParse); ok {
+		return fn(s)
+	}
+	return parseSemver(s)
+}
+
+// Valid reports whether s is a valid semantic version.
+//
+// Example 1:
+//
+//	{{ semver.Valid "1.2.3" }} // Output: true
+//
+// Example 2:
+//
+//	{{ semver.Valid "1.02" }} // Output: false
+func (ctx Semver) Valid(s string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverValid]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.SemverValid}
+	}
+	if fn, ok := override[func(string) (bool, error)](ctx.options, funcs.SemverValid); ok {
+		return fn(s)
+	}
+	_, err := parseSemver(s)
+	return err == nil, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether a is lower, equal or higher than b.
+//
+// Example:
+//
+//	{{ semver.Compare "1.10.0" "1.9.0" }} // Output: 1
+func (ctx Semver) Compare(a, b any) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverCompare]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.SemverCompare}
+	}
+	if fn, ok := override[func(any, any) (int, error)](ctx.options, funcs.SemverCompare); ok {
+		return fn(a, b)
+	}
+	va, err := toSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := toSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// Sort parses the versions in the slice and returns them in ascending order.
+//
+// Example:
+//
+//	{{ semver.Sort ( slice.New "1.10.0" "1.2.0" "1.2.0-rc.1" ) }} // Output: [1.2.0-rc.1 1.2.0 1.10.0]
+func (ctx Semver) Sort(versions any) ([]SemverValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverSort]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.SemverSort}
+	}
+	if fn, ok := override[func(any) ([]SemverValue, error)](ctx.options, funcs.SemverSort); ok {
+		return fn(versions)
+	}
+	items, err := sliceToAny(versions)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SemverValue, len(items))
+	for i, item := range items {
+		if out[i], err = toSemver(item); err != nil {
+			return nil, err
+		}
+	}
+	slices.SortStableFunc(out, SemverValue.Compare)
+	return out, nil
+}
+
+// BumpMajor increments the major version and resets minor and patch, a pre-release of a new major
+// version (e.g. 2.0.0-rc.1) becomes its release. Build metadata is removed.
+//
+// Example:
+//
+//	{{ semver.BumpMajor "1.2.3" }} // Output: 2.0.0
+func (ctx Semver) BumpMajor(v any) (SemverValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverBumpMajor]; !ok {
+		return SemverValue{}, &FuncNotAllowedError{Func: funcs.SemverBumpMajor}
+	}
+	if fn, ok := override[func(any) (SemverValue, error)](ctx.options, funcs.SemverBumpMajor); ok {
+		return fn(v)
+	}
+	sv, err := toSemver(v)
+	if err != nil {
+		return SemverValue{}, err
+	}
+	if sv.Prerelease == "" || sv.Minor != 0 || sv.Patch != 0 {
+		if sv.Major == math.MaxUint64 {
+			return SemverValue{}, &OverflowError{Func: funcs.SemverBumpMajor}
+		}
+		sv.Major++
+	}
+	return SemverValue{Major: sv.Major, Minor: 0, Patch: 0, Prerelease: "", Build: ""}, nil
+}
+
+// BumpMinor increments the minor version and resets patch, a pre-release of a new minor
+// version (e.g. 1.3.0-rc.1) becomes its release. Build metadata is removed.
+//
+// Example:
+//
+//	{{ semver.BumpMinor "1.2.3" }} // Output: 1.3.0
+func (ctx Semver) BumpMinor(v any) (SemverValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverBumpMinor]; !ok {
+		return SemverValue{}, &FuncNotAllowedError{Func: funcs.SemverBumpMinor}
+	}
+	if fn, ok := override[func(any) (SemverValue, error)](ctx.options, funcs.SemverBumpMinor); ok {
+		return fn(v)
+	}
+	sv, err := toSemver(v)
+	if err != nil {
+		return SemverValue{}, err
+	}
+	if sv.Prerelease == "" || sv.Patch != 0 {
+		if sv.Minor == math.MaxUint64 {
+			return SemverValue{}, &OverflowError{Func: funcs.SemverBumpMinor}
+		}
+		sv.Minor++
+	}
+	return SemverValue{Major: sv.Major, Minor: sv.Minor, Patch: 0, Prerelease: "", Build: ""}, nil
+}
+
+// BumpPatch increments the patch version, a pre-release (e.g. 1.2.3-rc.1) becomes its release.
+// Build metadata is removed.
+//
+// Example 1:
+//
+//	{{ semver.BumpPatch "1.2.3" }} // Output: 1.2.4
+//
+// Example 2:
+//
+//	{{ semver.BumpPatch "1.2.3-rc.1" }} // Output: 1.2.3
+func (ctx Semver) BumpPatch(v any) (SemverValue, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverBumpPatch]; !ok {
+		return SemverValue{}, &FuncNotAllowedError{Func: funcs.SemverBumpPatch}
+	}
+	if fn, ok := override[func(any) (SemverValue, error)](ctx.options, funcs.SemverBumpPatch); ok {
+		return fn(v)
+	}
+	sv, err := toSemver(v)
+	if err != nil {
+		return SemverValue{}, err
+	}
+	if sv.Prerelease == "" {
+		if sv.Patch == math.MaxUint64 {
+			return SemverValue{}, &OverflowError{Func: funcs.SemverBumpPatch}
+		}
+		sv.Patch++
+	}
+	return SemverValue{Major: sv.Major, Minor: sv.Minor, Patch: sv.Patch, Prerelease: "", Build: ""}, nil
+}
+
+// Satisfies reports whether v matches the constraint, see Semver for the constraint syntax.
+//
+// Example 1:
+//
+//	{{ semver.Satisfies ">=1.2.0, <2.0.0" "1.4.2" }} // Output: true
+//
+// Example 2:
+//
+//	{{ semver.Satisfies "^1.4" "1.3.9" }} // Output: false
+func (ctx Semver) Satisfies(constraint string, v any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.SemverSatisfies]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.SemverSatisfies}
+	}
+	if fn, ok := override[func(string, any) (bool, error)](ctx.options, funcs.SemverSatisfies); ok {
+		return fn(constraint, v)
+	}
+	groups, err := parseSemverConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	sv, err := toSemver(v)
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groups {
+		if group.matches(sv) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// semverComparator compares a version with op, explicit is set when the constraint contains the pre-release.
+type semverComparator struct {
+	op       string
+	version  SemverValue
+	explicit bool
+}
+
+type semverGroup []semverComparator
+
+func (g semverGroup) matches(v SemverValue) bool {
+	allowPrerelease := v.Prerelease == ""
+	for _, c := range g {
+		r := v.Compare(c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = r == 0
+		case "!=":
+			ok = r != 0
+		case ">":
+			ok = r > 0
+		case ">=":
+			ok = r >= 0
+		case "<":
+			ok = r < 0
+		case "<=":
+			ok = r <= 0
+		}
+		if !ok {
+			return false
+		}
+		if c.explicit && c.version.Major == v.Major && c.version.Minor == v.Minor && c.version.Patch == v.Patch {
+			allowPrerelease = true
+		}
+	}
+	return allowPrerelease
+}
+
+//nolint:gochecknoglobals // lookup table
+var semverComparatorRegexp = regexp.MustCompile(`(\^|~|>=|<=|!=|>|<|=)?\s*v?` +
+	`([0-9]+|[xX*])(?:\.([0-9]+|[xX*]))?(?:\.([0-9]+|[xX*]))?` +
+	`(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?`)
+
+func parseSemverConstraint(constraint string) ([]semverGroup, error) {
+	invalid := &InvalidConstraintError{Constraint: constraint}
+	var groups []semverGroup
+	for part := range strings.SplitSeq(constraint, "||") {
+		part = strings.TrimSpace(part)
+		var group semverGroup
+		last := 0
+		for _, m := range semverComparatorRegexp.FindAllStringSubmatchIndex(part, -1) {
+			if strings.Trim(part[last:m[0]], " ,") != "" || (last > 0 && last == m[0]) {
+				return nil, invalid
+			}
+			last = m[1]
+			sub := func(i int) string {
+				if m[2*i] < 0 {
+					return ""
+				}
+				return part[m[2*i]:m[2*i+1]]
+			}
+			comparators, err := expandSemverComparator(sub(1), []string{sub(2), sub(3), sub(4)}, sub(5))
+			if err != nil {
+				return nil, invalid
+			}
+			group = append(group, comparators...)
+		}
+		if strings.Trim(part[last:], " ,") != "" {
+			return nil, invalid
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// expandSemverComparator converts an operator and a possibly partial version to comparators.
+//
+//nolint:cyclop, funlen // cannot be simplified
+func expandSemverComparator(op string, parts []string, prerelease string) ([]semverComparator, error) {
+	var nums [3]uint64
+	n := 0
+	for _, p := range parts {
+		if p == "" || p == "x" || p == "X" || p == "*" {
+			break
+		}
+		var err error
+		if nums[n], err = strconv.ParseUint(p, 10, 64); err != nil {
+			return nil, err
+		}
+		n++
+	}
+	if prerelease != "" && n < 3 {
+		return nil, ErrInvalidSemver
+	}
+	lower := SemverValue{Major: nums[0], Minor: nums[1], Patch: nums[2], Prerelease: prerelease, Build: ""}
+	// upper returns the lowest version above the first k parts, e.g. 1.3.0-0 for 1.2.x
+	upper := func(k int) SemverValue {
+		v := SemverValue{Major: nums[0], Minor: nums[1], Patch: nums[2], Prerelease: "0", Build: ""}
+		switch k {
+		case 1:
+			v.Major, v.Minor, v.Patch = v.Major+1, 0, 0
+		case 2:
+			v.Minor, v.Patch = v.Minor+1, 0
+		default:
+			v.Patch++
+		}
+		return v
+	}
+	// only the version of the constraint carries an explicit pre-release, the others are bounds
+	c := func(op string, v SemverValue) semverComparator {
+		return semverComparator{op: op, version: v, explicit: prerelease != "" && v == lower}
+	}
+	lowest := SemverValue{Major: 0, Minor: 0, Patch: 0, Prerelease: "0", Build: ""}
+	if n == 0 {
+		switch op {
+		case "", "=", ">=", "<=", "^", "~":
+			return nil, nil
+		default:
+			// nothing is greater or lower than every version
+			return []semverComparator{c("<", lowest)}, nil
+		}
+	}
+	switch op {
+	case "", "=":
+		if n == 3 {
+			return []semverComparator{c("=", lower)}, nil
+		}
+		return []semverComparator{c(">=", lower), c("<", upper(n))}, nil
+	case "!=":
+		if n < 3 {
+			return nil, ErrInvalidSemver
+		}
+		return []semverComparator{c("!=", lower)}, nil
+	case ">":
+		if n == 3 {
+			return []semverComparator{c(">", lower)}, nil
+		}
+		next := upper(n)
+		next.Prerelease = ""
+		return []semverComparator{c(">=", next)}, nil
+	case ">=":
+		return []semverComparator{c(">=", lower)}, nil
+	case "<":
+		if n == 3 {
+			return []semverComparator{c("<", lower)}, nil
+		}
+		lower.Prerelease = "0"
+		return []semverComparator{c("<", lower)}, nil
+	case "<=":
+		if n == 3 {
+			return []semverComparator{c("<=", lower)}, nil
+		}
+		return []semverComparator{c("<", upper(n))}, nil
+	case "~":
+		return []semverComparator{c(">=", lower), c("<", upper(min(n, 2)))}, nil
+	default: // "^"
+		k := 3
+		switch {
+		case nums[0] != 0 || n == 1:
+			k = 1
+		case nums[1] != 0 || n == 2:
+			k = 2
+		}
+		return []semverComparator{c(">=", lower), c("<", upper(k))}, nil
+	}
+}
@@ -0,0 +1,118 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleSemver_BumpMajor() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.BumpMajor "1.2.3" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2.0.0
+}
+
+func ExampleSemver_BumpMinor() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.BumpMinor "1.2.3" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.3.0
+}
+
+func ExampleSemver_BumpPatch() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.BumpPatch "1.2.3" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.2.4
+}
+
+func ExampleSemver_BumpPatch_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.BumpPatch "1.2.3-rc.1" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.2.3
+}
+
+func ExampleSemver_Compare() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Compare "1.10.0" "1.9.0" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1
+}
+
+func ExampleSemver_Parse() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Parse "v1.2.3-rc.1+build.5" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.2.3-rc.1+build.5
+}
+
+func ExampleSemver_Parse_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ ( semver.Parse "1.2.3-rc.1" ).Prerelease }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: rc.1
+}
+
+func ExampleSemver_Satisfies() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Satisfies ">=1.2.0, <2.0.0" "1.4.2" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleSemver_Satisfies_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Satisfies "^1.4" "1.3.9" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
+func ExampleSemver_Sort() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Sort ( slice.New "1.10.0" "1.2.0" "1.2.0-rc.1" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [1.2.0-rc.1 1.2.0 1.10.0]
+}
+
+func ExampleSemver_Valid() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Valid "1.2.3" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleSemver_Valid_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ semver.Valid "1.02" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
@@ -0,0 +1,203 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestSemver_Satisfies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: ">=1.2.0, <2.0.0", version: "1.2.0", want: true},
+		{constraint: ">=1.2.0, <2.0.0", version: "2.0.0", want: false},
+		{constraint: ">= 1.2.0 < 2.0.0", version: "1.9.9", want: true},
+		{constraint: "1.2.3", version: "1.2.3+build", want: true},
+		{constraint: "=v1.2.3", version: "1.2.4", want: false},
+		{constraint: "!=1.2.3", version: "1.2.4", want: true},
+		{constraint: "^1.4", version: "1.4.0", want: true},
+		{constraint: "^1.4", version: "1.99.0", want: true},
+		{constraint: "^1.4", version: "2.0.0", want: false},
+		{constraint: "^0.2.3", version: "0.2.9", want: true},
+		{constraint: "^0.2.3", version: "0.3.0", want: false},
+		{constraint: "^0.0.3", version: "0.0.4", want: false},
+		{constraint: "~1.2.3", version: "1.2.9", want: true},
+		{constraint: "~1.2.3", version: "1.3.0", want: false},
+		{constraint: "~1", version: "1.9.0", want: true},
+		{constraint: "1.2.x", version: "1.2.7", want: true},
+		{constraint: "1.*", version: "2.0.0", want: false},
+		{constraint: "*", version: "0.0.1", want: true},
+		{constraint: "", version: "3.0.0", want: true},
+		{constraint: ">1.2", version: "1.2.9", want: false},
+		{constraint: ">1.2", version: "1.3.0", want: true},
+		{constraint: "<=1.2", version: "1.2.9", want: true},
+		{constraint: "<1.2", version: "1.1.9", want: true},
+		{constraint: ">*", version: "1.0.0", want: false},
+		{constraint: "<1.0.0 || >=2.0.0", version: "2.1.0", want: true},
+		{constraint: "<1.0.0 || >=2.0.0", version: "1.1.0", want: false},
+		{constraint: ">=1.2.0", version: "1.3.0-rc.1", want: false},
+		{constraint: ">=1.3.0-rc.1", version: "1.3.0-rc.2", want: true},
+		{constraint: ">=1.3.0-rc.1", version: "1.4.0-rc.2", want: false},
+		{constraint: "^1.3.0-rc.1", version: "1.3.0", want: true},
+		{constraint: "<2.0.0", version: "2.0.0-rc.1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(`{{ semver.Satisfies .C .V }}`,
+				map[string]string{"C": tt.constraint, "V": tt.version}, funcs.Semver)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if want := map[bool]string{true: "true", false: "false"}[tt.want]; got != want {
+				t.Errorf("Satisfies(%q, %q) = %s, want %s", tt.constraint, tt.version, got, want)
+			}
+		})
+	}
+}
+
+func TestSemver_InvalidConstraint(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{">=", "1.2.3 - 2.0.0", ">=1.2.0<2.0.0", "foo", "!=1.2", "1.2-rc.1", "||"} {
+		_, err := xtemplate.QuickExecute(`{{ semver.Satisfies . "1.0.0" }}`, constraint, funcs.Semver)
+		var constraintErr *xtemplate.InvalidConstraintError
+		if constraint == "||" {
+			// empty alternatives match every version
+			if err != nil {
+				t.Errorf("Satisfies(%q) error = %v", constraint, err)
+			}
+			continue
+		}
+		if !errors.As(err, &constraintErr) || constraintErr.Constraint != constraint {
+			t.Errorf("Satisfies(%q) error = %v, want InvalidConstraintError", constraint, err)
+		}
+	}
+}
+
+func TestSemver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{
+			name: "precedence",
+			tmpl: `{{ semver.Sort . }}`,
+			data: []string{
+				"1.0.0", "1.0.0-rc.1", "1.0.0-beta.11", "1.0.0-beta.2", "1.0.0-beta", "1.0.0-alpha.beta",
+				"1.0.0-alpha.1", "1.0.0-alpha", "0.9.10", "0.9.9",
+			},
+			want: "[0.9.9 0.9.10 1.0.0-alpha 1.0.0-alpha.1 1.0.0-alpha.beta 1.0.0-beta 1.0.0-beta.2 " +
+				"1.0.0-beta.11 1.0.0-rc.1 1.0.0]",
+		},
+		{
+			name: "slice sort and reverse",
+			tmpl: `{{ slice.Reverse ( slice.Sort . ) }}`,
+			data: []xtemplate.SemverValue{{Major: 1, Minor: 10}, {Major: 1, Minor: 9}, {Major: 1, Minor: 10, Patch: 1}},
+			want: "[1.10.1 1.10.0 1.9.0]",
+		},
+		{
+			name: "slice new sort and reverse",
+			tmpl: `{{ $s := slice.New (semver.Parse "1.10.0") (semver.Parse "1.9.0") (semver.Parse "1.10.0-rc.1") }}` +
+				`{{ slice.Sort $s }} {{ slice.Reverse (slice.Sort $s) }}`,
+			want: "[1.9.0 1.10.0-rc.1 1.10.0] [1.10.0 1.10.0-rc.1 1.9.0]",
+		},
+		{
+			name: "fields",
+			tmpl: `{{ $v := semver.Parse . }}{{ $v.Major }} {{ $v.Minor }} {{ $v.Patch }} ` +
+				`{{ $v.Prerelease }} {{ $v.Build }}`,
+			data: "v10.20.30-rc.1+sha.abc",
+			want: "10 20 30 rc.1 sha.abc",
+		},
+		{
+			name: "compare ignores build",
+			tmpl: `{{ semver.Compare "1.0.0+a" "1.0.0+b" }} ` +
+				`{{ semver.Compare ( semver.Parse "1.0.0-rc.1" ) "1.0.0" }}`,
+			want: "0 -1",
+		},
+		{
+			name: "bump",
+			tmpl: `{{ semver.BumpMajor "1.2.3-rc.1+b" }} {{ semver.BumpMajor "2.0.0-rc.1" }} ` +
+				`{{ semver.BumpMinor "1.2.3-rc.1" }} {{ semver.BumpMinor "1.3.0-rc.1" }} {{ semver.BumpPatch "1.2.3+b" }}`,
+			want: "2.0.0 2.0.0 1.3.0 1.3.0 1.2.4",
+		},
+		{
+			name: "json",
+			tmpl: `{{ conv.ToString ( json.Marshal ( semver.Parse "1.2.3" ) ) }}`,
+			want: `"1.2.3"`,
+		},
+		{
+			name: "valid",
+			tmpl: `{{ semver.Valid "1.2.3-0" }} {{ semver.Valid "1.2.3-01" }} {{ semver.Valid "1.2" }}`,
+			want: "true false false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Safe)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	_, err := xtemplate.QuickExecute(`{{ semver.Parse "1.2" }}`, nil, funcs.Semver)
+	if !errors.Is(err, xtemplate.ErrInvalidSemver) {
+		t.Errorf("Parse() error = %v, want %v", err, xtemplate.ErrInvalidSemver)
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ slice.Sort (slice.New (semver.Parse "1.0.0") "0.9.0") }}`, nil, funcs.Safe)
+	if !errors.Is(err, xtemplate.ErrCannotSortAnySlice) {
+		t.Errorf("Sort() error = %v, want %v", err, xtemplate.ErrCannotSortAnySlice)
+	}
+}
+
+func TestSemver_BumpOverflow(t *testing.T) {
+	t.Parallel()
+
+	const maxPart = "18446744073709551615"
+	tests := []struct {
+		name string
+		tmpl string
+	}{
+		{name: "major", tmpl: `{{ semver.BumpMajor "` + maxPart + `.0.0" }}`},
+		{name: "minor", tmpl: `{{ semver.BumpMinor "1.` + maxPart + `.0" }}`},
+		{name: "patch", tmpl: `{{ semver.BumpPatch "1.2.` + maxPart + `" }}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Semver)
+			var overflowErr *xtemplate.OverflowError
+			if !errors.As(err, &overflowErr) {
+				t.Errorf("QuickExecute() error = %v, want %T", err, overflowErr)
+			}
+		})
+	}
+
+	got, err := xtemplate.QuickExecute(`{{ semver.BumpMajor "`+maxPart+`.0.0-rc.1" }}`, nil, funcs.Semver)
+	if err != nil || got != maxPart+".0.0" {
+		t.Errorf("BumpMajor() = %q, %v, want %q", got, err, maxPart+".0.0")
+	}
+}
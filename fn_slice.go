@@ -11,7 +11,7 @@ import (
 // Slice provides helper functions for slices.
 type Slice rootContext
 
-// ErrCannotSortAnySlice is returned when trying to sort a []any slice.
+// ErrCannotSortAnySlice is returned when trying to sort a []any slice that does not only contain semantic versions.
 var ErrCannotSortAnySlice = errors.New("cannot sort []any slices")
 
 // ErrArgNotSlice is returned when the argument provided is not a slice.
@@ -32,6 +32,9 @@ func (ctx Slice) New(vals ...any) ([]any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceNew]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceNew}
 	}
+	if fn, ok := override[func(...any) ([]any, error)](ctx.options, funcs.SliceNew); ok {
+		return fn(vals...)
+	}
 
 	return vals, nil
 }
@@ -45,6 +48,9 @@ func (ctx Slice) NewStrings(vals ...any) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceNewStrings]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceNewStrings}
 	}
+	if fn, ok := override[func(...any) ([]string, error)](ctx.options, funcs.SliceNewStrings); ok {
+		return fn(vals...)
+	}
 
 	return toStrings(vals), nil
 }
@@ -58,6 +64,9 @@ func (ctx Slice) NewInts(vals ...any) ([]int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceNewInts]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceNewInts}
 	}
+	if fn, ok := override[func(...any) ([]int, error)](ctx.options, funcs.SliceNewInts); ok {
+		return fn(vals...)
+	}
 
 	return toInts[int](vals, math.MinInt, math.MaxInt)
 }
@@ -71,6 +80,9 @@ func (ctx Slice) NewInt64s(vals ...any) ([]int64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceNewInt64s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceNewInt64s}
 	}
+	if fn, ok := override[func(...any) ([]int64, error)](ctx.options, funcs.SliceNewInt64s); ok {
+		return fn(vals...)
+	}
 
 	return toInt64s(vals)
 }
@@ -84,6 +96,9 @@ func (ctx Slice) NewFloat64s(vals ...any) ([]float64, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceNewFloat64s]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceNewFloat64s}
 	}
+	if fn, ok := override[func(...any) ([]float64, error)](ctx.options, funcs.SliceNewFloat64s); ok {
+		return fn(vals...)
+	}
 
 	return toFloat64s(vals)
 }
@@ -97,6 +112,9 @@ func (ctx Slice) NewBools(vals ...any) ([]bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceNewBools]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceNewBools}
 	}
+	if fn, ok := override[func(...any) ([]bool, error)](ctx.options, funcs.SliceNewBools); ok {
+		return fn(vals...)
+	}
 
 	return toBools(vals), nil
 }
@@ -113,6 +131,9 @@ func (ctx Slice) Contains(s any, v any) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceContains]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.SliceContains}
 	}
+	if fn, ok := override[func(any, any) (bool, error)](ctx.options, funcs.SliceContains); ok {
+		return fn(s, v)
+	}
 	switch sl := s.(type) {
 	case []any:
 		for i := range sl {
@@ -159,6 +180,19 @@ func toAnySlice[T any](in []T) []any {
 	return n
 }
 
+// toSemverValues returns the elements of s if all of them are semantic versions.
+func toSemverValues(s []any) ([]SemverValue, bool) {
+	versions := make([]SemverValue, len(s))
+	for i, v := range s {
+		sv, ok := v.(SemverValue)
+		if !ok {
+			return nil, false
+		}
+		versions[i] = sv
+	}
+	return versions, true
+}
+
 // Reverse reverses the order of elements in the provided slice.
 //
 // Example:
@@ -170,6 +204,9 @@ func (ctx Slice) Reverse(s any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceReverse]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.SliceReverse}
 	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.SliceReverse); ok {
+		return fn(s)
+	}
 	switch sl := s.(type) {
 	case []any:
 		sl = slices.Clone(sl)
@@ -201,11 +238,14 @@ func (ctx Slice) Reverse(s any) (any, error) {
 		return ctx.Reverse(toAnySlice(sl))
 	case []uint64:
 		return ctx.Reverse(toAnySlice(sl))
+	case []SemverValue:
+		return ctx.Reverse(toAnySlice(sl))
 	}
 	return false, ErrArgNotSlice
 }
 
-// Sort sorts the provided slice.
+// Sort sorts the provided slice, semantic versions are sorted by their precedence.
+// A []any slice (e.g. created by slice.New) can only be sorted if all elements are semantic versions.
 //
 // Example:
 //
@@ -216,9 +256,17 @@ func (ctx Slice) Sort(s any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceSort]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.SliceSort}
 	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.SliceSort); ok {
+		return fn(s)
+	}
 	switch sl := s.(type) {
 	case []any:
-		return nil, ErrCannotSortAnySlice
+		versions, ok := toSemverValues(sl)
+		if !ok {
+			return nil, ErrCannotSortAnySlice
+		}
+		slices.SortStableFunc(versions, SemverValue.Compare)
+		return toAnySlice(versions), nil
 	case []bool:
 		sl = slices.Clone(sl)
 		sortBool(sl)
@@ -271,6 +319,10 @@ func (ctx Slice) Sort(s any) (any, error) {
 		sl = slices.Clone(sl)
 		slices.Sort(sl)
 		return sl, nil
+	case []SemverValue:
+		sl = slices.Clone(sl)
+		slices.SortStableFunc(sl, SemverValue.Compare)
+		return sl, nil
 	}
 	return false, ErrArgNotSlice
 }
@@ -287,6 +339,9 @@ func (ctx Slice) Append(s any, vals ...any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceAppend]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceAppend}
 	}
+	if fn, ok := override[func(any, ...any) (any, error)](ctx.options, funcs.SliceAppend); ok {
+		return fn(s, vals...)
+	}
 	switch sl := s.(type) {
 	case []any:
 		return append(sl, vals...), nil
@@ -376,6 +431,9 @@ func (ctx Slice) Prepend(s any, vals ...any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SlicePrepend]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SlicePrepend}
 	}
+	if fn, ok := override[func(any, ...any) (any, error)](ctx.options, funcs.SlicePrepend); ok {
+		return fn(s, vals...)
+	}
 	switch sl := s.(type) {
 	case []any:
 		return append(vals, sl...), nil
@@ -465,6 +523,9 @@ func (ctx Slice) Len(s any) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceLen]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.SliceLen}
 	}
+	if fn, ok := override[func(any) (int, error)](ctx.options, funcs.SliceLen); ok {
+		return fn(s)
+	}
 	switch sl := s.(type) {
 	case []any:
 		return len(sl), nil
@@ -520,6 +581,9 @@ func (ctx Slice) Unique(s any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceUnique]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceUnique}
 	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.SliceUnique); ok {
+		return fn(s)
+	}
 	switch sl := s.(type) {
 	case []any:
 		return uniqSlice(sl), nil
@@ -565,6 +629,9 @@ func (ctx Slice) Compact(s any) (any, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceCompact]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.SliceCompact}
 	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.SliceCompact); ok {
+		return fn(s)
+	}
 	switch sl := s.(type) {
 	case []any:
 		return nil, ErrCannotCompactAnySlice
@@ -610,6 +677,9 @@ func (ctx Slice) IsEmpty(s any) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.SliceIsEmpty]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.SliceIsEmpty}
 	}
+	if fn, ok := override[func(any) (bool, error)](ctx.options, funcs.SliceIsEmpty); ok {
+		return fn(s)
+	}
 	switch sl := s.(type) {
 	case []any:
 		return len(sl) == 0, nil
@@ -0,0 +1,248 @@
+package xtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Strconv provides functions to quote strings and to format and parse numbers in other bases and formats.
+type Strconv rootContext
+
+// maxFloatPrecision limits the precision of FormatFloat to keep the size of the result reasonable.
+const maxFloatPrecision = 1000
+
+// Quote returns a double-quoted Go string literal representing s.
+//
+// Example:
+//
+//	{{ strconv.Quote "Hello\tWorld \"☺\"" }} // Output: "Hello\tWorld \"☺\""
+func (ctx Strconv) Quote(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvQuote]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvQuote}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StrconvQuote); ok {
+		return fn(s)
+	}
+	return strconv.Quote(s), nil
+}
+
+// QuoteToASCII returns a double-quoted Go string literal representing s,
+// non-ASCII characters are escaped.
+//
+// Example:
+//
+//	{{ strconv.QuoteToASCII "☺" }} // Output: "\u263a"
+func (ctx Strconv) QuoteToASCII(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvQuoteToASCII]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvQuoteToASCII}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StrconvQuoteToASCII); ok {
+		return fn(s)
+	}
+	return strconv.QuoteToASCII(s), nil
+}
+
+// QuoteJSON returns a JSON string literal representing s, HTML characters are not escaped.
+//
+// Example:
+//
+//	{{ strconv.QuoteJSON "<a>\t\"☺\"" }} // Output: "<a>\t\"☺\""
+func (ctx Strconv) QuoteJSON(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvQuoteJSON]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvQuoteJSON}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StrconvQuoteJSON); ok {
+		return fn(s)
+	}
+	return quoteJSON(s)
+}
+
+func quoteJSON(s string) (string, error) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// AppendQuote returns dst followed by the double-quoted Go string literal representing s.
+//
+// Example:
+//
+//	{{ strconv.AppendQuote "var s = " "a\nb" }} // Output: var s = "a\nb"
+func (ctx Strconv) AppendQuote(dst, s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvAppendQuote]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvAppendQuote}
+	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StrconvAppendQuote); ok {
+		return fn(dst, s)
+	}
+	return string(strconv.AppendQuote([]byte(dst), s)), nil
+}
+
+// AppendQuoteToASCII returns dst followed by the double-quoted Go string literal representing s,
+// non-ASCII characters are escaped.
+//
+// Example:
+//
+//	{{ strconv.AppendQuoteToASCII "name: " "Jürgen" }} // Output: name: "J\u00fcrgen"
+func (ctx Strconv) AppendQuoteToASCII(dst, s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvAppendQuoteToASCII]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvAppendQuoteToASCII}
+	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StrconvAppendQuoteToASCII); ok {
+		return fn(dst, s)
+	}
+	return string(strconv.AppendQuoteToASCII([]byte(dst), s)), nil
+}
+
+// AppendQuoteJSON returns dst followed by the JSON string literal representing s.
+//
+// Example:
+//
+//	{{ strconv.AppendQuoteJSON "\"name\": " "Jürgen" }} // Output: "name": "Jürgen"
+func (ctx Strconv) AppendQuoteJSON(dst, s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvAppendQuoteJSON]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvAppendQuoteJSON}
+	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StrconvAppendQuoteJSON); ok {
+		return fn(dst, s)
+	}
+	q, err := quoteJSON(s)
+	if err != nil {
+		return "", err
+	}
+	return dst + q, nil
+}
+
+// CanBackquote reports whether s can be represented as a raw (backquoted) Go string literal.
+//
+// Example:
+//
+//	{{ strconv.CanBackquote "C:\\path" }} // Output: true
+func (ctx Strconv) CanBackquote(s string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvCanBackquote]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.StrconvCanBackquote}
+	}
+	if fn, ok := override[func(string) (bool, error)](ctx.options, funcs.StrconvCanBackquote); ok {
+		return fn(s)
+	}
+	return strconv.CanBackquote(s), nil
+}
+
+// Unquote interprets s as a single-quoted, double-quoted, or backquoted Go string literal
+// and returns the string value that s quotes.
+//
+// Example:
+//
+//	{{ strconv.Unquote "\"a\\tb\"" }} // Output: a	b
+func (ctx Strconv) Unquote(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvUnquote]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvUnquote}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StrconvUnquote); ok {
+		return fn(s)
+	}
+	return strconv.Unquote(s)
+}
+
+// FormatInt returns the string representation of i in the given base, for 2 <= base <= 36.
+//
+// Example 1:
+//
+//	{{ strconv.FormatInt 255 16 }} // Output: ff
+//
+// Example 2:
+//
+//	{{ strconv.FormatInt -5 2 }} // Output: -101
+func (ctx Strconv) FormatInt(i any, base int) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvFormatInt]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvFormatInt}
+	}
+	if fn, ok := override[func(any, int) (string, error)](ctx.options, funcs.StrconvFormatInt); ok {
+		return fn(i, base)
+	}
+	if base < 2 || base > 36 {
+		return "", fmt.Errorf("%w: base %d", ErrInvalidArgument, base)
+	}
+	n, err := toInt64(i)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(n, base), nil
+}
+
+// ParseInt interprets s in the given base (0, 2 to 36) and returns the value.
+// For base 0 the base is implied by the prefix: 0b, 0o or 0, 0x and 10 otherwise, underscores are permitted.
+// The optional bitSize (0, 8, 16, 32 or 64) specifies the integer type the result must fit into, it defaults to 64.
+//
+// Example 1:
+//
+//	{{ strconv.ParseInt "ff" 16 }} // Output: 255
+//
+// Example 2:
+//
+//	{{ strconv.ParseInt "0b1_000" 0 }} // Output: 8
+func (ctx Strconv) ParseInt(s string, base int, bitSize ...int) (int64, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvParseInt]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.StrconvParseInt}
+	}
+	if fn, ok := override[func(string, int, ...int) (int64, error)](ctx.options, funcs.StrconvParseInt); ok {
+		return fn(s, base, bitSize...)
+	}
+	if len(bitSize) > 1 {
+		return 0, OnlyOneArgumentIsAllowedError{}
+	}
+	size := 64
+	if len(bitSize) == 1 {
+		size = bitSize[0]
+	}
+	return strconv.ParseInt(s, base, size)
+}
+
+// FormatFloat converts f to a string according to the format and precision.
+// The format is one of 'b', 'e', 'E', 'f', 'g', 'G', 'x' or 'X', the precision -1 uses the smallest
+// number of digits necessary to represent the value exactly. The optional bitSize (32 or 64) defaults to 64.
+//
+// Example 1:
+//
+//	{{ strconv.FormatFloat 3.14159 "f" 2 }} // Output: 3.14
+//
+// Example 2:
+//
+//	{{ strconv.FormatFloat 1234.5 "e" -1 }} // Output: 1.2345e+03
+func (ctx Strconv) FormatFloat(f any, format string, prec int, bitSize ...int) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StrconvFormatFloat]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.StrconvFormatFloat}
+	}
+	if fn, ok := override[func(any, string, int, ...int) (string, error)](ctx.options, funcs.StrconvFormatFloat); ok {
+		return fn(f, format, prec, bitSize...)
+	}
+	if len(bitSize) > 1 {
+		return "", OnlyOneArgumentIsAllowedError{}
+	}
+	size := 64
+	if len(bitSize) == 1 {
+		size = bitSize[0]
+	}
+	if size != 32 && size != 64 {
+		return "", fmt.Errorf("%w: bit size %d", ErrInvalidArgument, size)
+	}
+	if len(format) != 1 || !strings.Contains("beEfgGxX", format) {
+		return "", fmt.Errorf("%w: format %q", ErrInvalidArgument, format)
+	}
+	if prec < -1 || prec > maxFloatPrecision {
+		return "", fmt.Errorf("%w: precision %d", ErrInvalidArgument, prec)
+	}
+	v, err := toFloat64(f)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(v, format[0], prec, size), nil
+}
@@ -0,0 +1,136 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleStrconv_AppendQuote() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.AppendQuote "var s = " "a\nb" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: var s = "a\nb"
+}
+
+func ExampleStrconv_AppendQuoteJSON() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.AppendQuoteJSON "\"name\": " "Jürgen" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "name": "Jürgen"
+}
+
+func ExampleStrconv_AppendQuoteToASCII() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.AppendQuoteToASCII "name: " "Jürgen" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: name: "J\u00fcrgen"
+}
+
+func ExampleStrconv_CanBackquote() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.CanBackquote "C:\\path" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleStrconv_FormatFloat() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.FormatFloat 3.14159 "f" 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 3.14
+}
+
+func ExampleStrconv_FormatFloat_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.FormatFloat 1234.5 "e" -1 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1.2345e+03
+}
+
+func ExampleStrconv_FormatInt() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.FormatInt 255 16 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: ff
+}
+
+func ExampleStrconv_FormatInt_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.FormatInt -5 2 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: -101
+}
+
+func ExampleStrconv_ParseInt() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.ParseInt "ff" 16 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 255
+}
+
+func ExampleStrconv_ParseInt_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.ParseInt "0b1_000" 0 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 8
+}
+
+func ExampleStrconv_Quote() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.Quote "Hello\tWorld \"☺\"" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "Hello\tWorld \"☺\""
+}
+
+func ExampleStrconv_QuoteJSON() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.QuoteJSON "<a>\t\"☺\"" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "<a>\t\"☺\""
+}
+
+func ExampleStrconv_QuoteToASCII() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.QuoteToASCII "☺" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "\u263a"
+}
+
+func ExampleStrconv_Unquote() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ strconv.Unquote "\"a\\tb\"" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a	b
+}
+
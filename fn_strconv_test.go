@@ -0,0 +1,98 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestStrconv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{
+			name: "quote control characters",
+			tmpl: `{{ strconv.Quote . }}`,
+			data: "a\x00\n\u2028",
+			want: `"a\x00\n\u2028"`,
+		},
+		{name: "quote json", tmpl: `{{ strconv.QuoteJSON . }}`, data: "a\x00\n\u2028&", want: `"a\u0000\n\u2028&"`},
+		{name: "unquote backquoted", tmpl: "{{ strconv.Unquote . }}", data: "`a\\n`", want: `a\n`},
+		{name: "unquote single quoted", tmpl: "{{ strconv.Unquote . }}", data: `'☺'`, want: "☺"},
+		{
+			name: "round trip",
+			tmpl: `{{ strconv.Unquote ( strconv.QuoteToASCII . ) }}`,
+			data: "Jürgen\t☺",
+			want: "Jürgen\t☺",
+		},
+		{name: "can backquote", tmpl: "{{ strconv.CanBackquote . }}", data: "a`b", want: "false"},
+		{name: "format int base 36", tmpl: `{{ strconv.FormatInt . 36 }}`, data: int64(1295), want: "zz"},
+		{name: "format int from string", tmpl: `{{ strconv.FormatInt . 8 }}`, data: "493", want: "755"},
+		{name: "parse int bit size", tmpl: `{{ strconv.ParseInt . 16 8 }}`, data: "-80", want: "-128"},
+		{name: "parse int octal", tmpl: `{{ strconv.ParseInt . 0 }}`, data: "0o755", want: "493"},
+		{name: "format float exact", tmpl: `{{ strconv.FormatFloat . "g" -1 }}`, data: 0.1, want: "0.1"},
+		{name: "format float 32", tmpl: `{{ strconv.FormatFloat . "g" -1 32 }}`, data: float32(0.1), want: "0.1"},
+		{name: "format float hex", tmpl: `{{ strconv.FormatFloat . "x" -1 }}`, data: 1.0, want: "0x1p+00"},
+		{name: "format float from int", tmpl: `{{ strconv.FormatFloat . "f" 3 }}`, data: 2, want: "2.000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Strconv)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrconv_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr error
+	}{
+		{name: "format int base too low", tmpl: `{{ strconv.FormatInt 1 1 }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{name: "format int base too high", tmpl: `{{ strconv.FormatInt 1 37 }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{name: "format float format", tmpl: `{{ strconv.FormatFloat 1 "d" 2 }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{
+			name:    "format float precision",
+			tmpl:    `{{ strconv.FormatFloat 1 "f" 100000 }}`,
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{
+			name:    "format float bit size",
+			tmpl:    `{{ strconv.FormatFloat 1 "f" 2 16 }}`,
+			wantErr: xtemplate.ErrInvalidArgument,
+		},
+		{name: "parse int range", tmpl: `{{ strconv.ParseInt "128" 10 8 }}`, wantErr: strconv.ErrRange},
+		{name: "parse int syntax", tmpl: `{{ strconv.ParseInt "12a" 10 }}`, wantErr: strconv.ErrSyntax},
+		{name: "unquote syntax", tmpl: `{{ strconv.Unquote "abc" }}`, wantErr: strconv.ErrSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Strconv)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -27,6 +27,9 @@ func (ctx Strings) Compare(a, b string) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsCompare]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsCompare}
 	}
+	if fn, ok := override[func(string, string) (int, error)](ctx.options, funcs.StringsCompare); ok {
+		return fn(a, b)
+	}
 	return strings.Compare(a, b), nil
 }
 
@@ -43,6 +46,9 @@ func (ctx Strings) Contains(s, substr string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsContains]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsContains}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.StringsContains); ok {
+		return fn(s, substr)
+	}
 	return strings.Contains(s, substr), nil
 }
 
@@ -59,6 +65,9 @@ func (ctx Strings) ContainsAny(s, chars string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsContainsAny]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsContainsAny}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.StringsContainsAny); ok {
+		return fn(s, chars)
+	}
 	return strings.ContainsAny(s, chars), nil
 }
 
@@ -75,6 +84,9 @@ func (ctx Strings) ContainsRune(s string, r rune) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsContainsRune]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsContainsRune}
 	}
+	if fn, ok := override[func(string, rune) (bool, error)](ctx.options, funcs.StringsContainsRune); ok {
+		return fn(s, r)
+	}
 	return strings.ContainsRune(s, r), nil
 }
 
@@ -96,6 +108,9 @@ func (ctx Strings) Count(s, substr string) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsCount]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsCount}
 	}
+	if fn, ok := override[func(string, string) (int, error)](ctx.options, funcs.StringsCount); ok {
+		return fn(s, substr)
+	}
 	return strings.Count(s, substr), nil
 }
 
@@ -116,6 +131,9 @@ func (ctx Strings) Cut(s, sep string) (CutResult, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsCut]; !ok {
 		return CutResult{}, &FuncNotAllowedError{Func: funcs.StringsCut}
 	}
+	if fn, ok := override[func(string, string) (CutResult, error)](ctx.options, funcs.StringsCut); ok {
+		return fn(s, sep)
+	}
 	before, after, found := strings.Cut(s, sep)
 	return CutResult{
 		Before: before,
@@ -140,6 +158,9 @@ func (ctx Strings) CutPrefix(s, sep string) (CutPrefixResult, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsCutPrefix]; !ok {
 		return CutPrefixResult{}, &FuncNotAllowedError{Func: funcs.StringsCutPrefix}
 	}
+	if fn, ok := override[func(string, string) (CutPrefixResult, error)](ctx.options, funcs.StringsCutPrefix); ok {
+		return fn(s, sep)
+	}
 	after, found := strings.CutPrefix(s, sep)
 	return CutPrefixResult{
 		After: after,
@@ -163,6 +184,9 @@ func (ctx Strings) CutSuffix(s, sep string) (CutSuffixResult, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsCutSuffix]; !ok {
 		return CutSuffixResult{}, &FuncNotAllowedError{Func: funcs.StringsCutSuffix}
 	}
+	if fn, ok := override[func(string, string) (CutSuffixResult, error)](ctx.options, funcs.StringsCutSuffix); ok {
+		return fn(s, sep)
+	}
 	before, found := strings.CutSuffix(s, sep)
 	return CutSuffixResult{
 		Before: before,
@@ -179,6 +203,9 @@ func (ctx Strings) Equal(s, t string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsEqual]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsEqual}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.StringsEqual); ok {
+		return fn(s, t)
+	}
 	return s == t, nil
 }
 
@@ -192,6 +219,9 @@ func (ctx Strings) EqualFold(s, t string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsEqualFold]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsEqualFold}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.StringsEqualFold); ok {
+		return fn(s, t)
+	}
 	return strings.EqualFold(s, t), nil
 }
 
@@ -205,6 +235,9 @@ func (ctx Strings) Fields(s string) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsFields]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.StringsFields}
 	}
+	if fn, ok := override[func(string) ([]string, error)](ctx.options, funcs.StringsFields); ok {
+		return fn(s)
+	}
 	return strings.Fields(s), nil
 }
 
@@ -217,6 +250,9 @@ func (ctx Strings) HasPrefix(s, prefix string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsHasPrefix]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsHasPrefix}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.StringsHasPrefix); ok {
+		return fn(s, prefix)
+	}
 	return strings.HasPrefix(s, prefix), nil
 }
 
@@ -229,6 +265,9 @@ func (ctx Strings) HasSuffix(s, suffix string) (bool, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsHasSuffix]; !ok {
 		return false, &FuncNotAllowedError{Func: funcs.StringsHasSuffix}
 	}
+	if fn, ok := override[func(string, string) (bool, error)](ctx.options, funcs.StringsHasSuffix); ok {
+		return fn(s, suffix)
+	}
 	return strings.HasSuffix(s, suffix), nil
 }
 
@@ -241,6 +280,9 @@ func (ctx Strings) Index(s, substr string) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsIndex]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsIndex}
 	}
+	if fn, ok := override[func(string, string) (int, error)](ctx.options, funcs.StringsIndex); ok {
+		return fn(s, substr)
+	}
 	return strings.Index(s, substr), nil
 }
 
@@ -251,6 +293,12 @@ func (ctx Strings) Index(s, substr string) (int, error) {
 //
 //	{{ strings.IndexAny "hello" "aeiou" }} // Output: 1
 func (ctx Strings) IndexAny(s1, chars string) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.StringsIndexAny]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.StringsIndexAny}
+	}
+	if fn, ok := override[func(string, string) (int, error)](ctx.options, funcs.StringsIndexAny); ok {
+		return fn(s1, chars)
+	}
 	return strings.IndexAny(s1, chars), nil
 }
 
@@ -263,6 +311,9 @@ func (ctx Strings) IndexByte(s string, c byte) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsIndexByte]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsIndexByte}
 	}
+	if fn, ok := override[func(string, byte) (int, error)](ctx.options, funcs.StringsIndexByte); ok {
+		return fn(s, c)
+	}
 	return strings.IndexByte(s, c), nil
 }
 
@@ -275,6 +326,9 @@ func (ctx Strings) IndexRune(s string, c rune) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsIndexRune]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsIndexRune}
 	}
+	if fn, ok := override[func(string, rune) (int, error)](ctx.options, funcs.StringsIndexRune); ok {
+		return fn(s, c)
+	}
 	return strings.IndexRune(s, c), nil
 }
 
@@ -288,6 +342,9 @@ func (ctx Strings) Join(a []string, sep string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsJoin]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsJoin}
 	}
+	if fn, ok := override[func([]string, string) (string, error)](ctx.options, funcs.StringsJoin); ok {
+		return fn(a, sep)
+	}
 	return strings.Join(a, sep), nil
 }
 
@@ -300,6 +357,9 @@ func (ctx Strings) LastIndex(s, substr string) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsLastIndex]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsLastIndex}
 	}
+	if fn, ok := override[func(string, string) (int, error)](ctx.options, funcs.StringsLastIndex); ok {
+		return fn(s, substr)
+	}
 	return strings.LastIndex(s, substr), nil
 }
 
@@ -313,6 +373,9 @@ func (ctx Strings) LastIndexAny(s, substr string) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsLastIndexAny]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsLastIndexAny}
 	}
+	if fn, ok := override[func(string, string) (int, error)](ctx.options, funcs.StringsLastIndexAny); ok {
+		return fn(s, substr)
+	}
 	return strings.LastIndexAny(s, substr), nil
 }
 
@@ -325,6 +388,9 @@ func (ctx Strings) LastIndexByte(s string, c byte) (int, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsLastIndexByte]; !ok {
 		return 0, &FuncNotAllowedError{Func: funcs.StringsLastIndexByte}
 	}
+	if fn, ok := override[func(string, byte) (int, error)](ctx.options, funcs.StringsLastIndexByte); ok {
+		return fn(s, c)
+	}
 	return strings.LastIndexByte(s, c), nil
 }
 
@@ -338,6 +404,9 @@ func (ctx Strings) Repeat(s string, count int) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsRepeat]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsRepeat}
 	}
+	if fn, ok := override[func(string, int) (string, error)](ctx.options, funcs.StringsRepeat); ok {
+		return fn(s, count)
+	}
 	return strings.Repeat(s, count), nil
 }
 
@@ -352,6 +421,9 @@ func (ctx Strings) Replace(s, old, replacement string, n int) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsReplace]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsReplace}
 	}
+	if fn, ok := override[func(string, string, string, int) (string, error)](ctx.options, funcs.StringsReplace); ok {
+		return fn(s, old, replacement, n)
+	}
 	return strings.Replace(s, old, replacement, n), nil
 }
 
@@ -366,6 +438,9 @@ func (ctx Strings) ReplaceAll(s, old, replacement string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsReplaceAll]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsReplaceAll}
 	}
+	if fn, ok := override[func(string, string, string) (string, error)](ctx.options, funcs.StringsReplaceAll); ok {
+		return fn(s, old, replacement)
+	}
 	return strings.ReplaceAll(s, old, replacement), nil
 }
 
@@ -379,6 +454,9 @@ func (ctx Strings) Split(s, sep string) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsSplit]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.StringsSplit}
 	}
+	if fn, ok := override[func(string, string) ([]string, error)](ctx.options, funcs.StringsSplit); ok {
+		return fn(s, sep)
+	}
 	return strings.Split(s, sep), nil
 }
 
@@ -392,6 +470,9 @@ func (ctx Strings) SplitAfter(s, sep string) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsSplitAfter]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.StringsSplitAfter}
 	}
+	if fn, ok := override[func(string, string) ([]string, error)](ctx.options, funcs.StringsSplitAfter); ok {
+		return fn(s, sep)
+	}
 	return strings.SplitAfter(s, sep), nil
 }
 
@@ -408,6 +489,9 @@ func (ctx Strings) SplitAfterN(s, sep string, n int) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsSplitAfterN]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.StringsSplitAfterN}
 	}
+	if fn, ok := override[func(string, string, int) ([]string, error)](ctx.options, funcs.StringsSplitAfterN); ok {
+		return fn(s, sep, n)
+	}
 	return strings.SplitAfterN(s, sep, n), nil
 }
 
@@ -424,6 +508,9 @@ func (ctx Strings) SplitN(s, sep string, n int) ([]string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsSplitN]; !ok {
 		return nil, &FuncNotAllowedError{Func: funcs.StringsSplitN}
 	}
+	if fn, ok := override[func(string, string, int) ([]string, error)](ctx.options, funcs.StringsSplitN); ok {
+		return fn(s, sep, n)
+	}
 	return strings.SplitN(s, sep, n), nil
 }
 
@@ -436,6 +523,9 @@ func (ctx Strings) ToLower(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsToLower]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsToLower}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StringsToLower); ok {
+		return fn(s)
+	}
 	return strings.ToLower(s), nil
 }
 
@@ -448,6 +538,9 @@ func (ctx Strings) ToTitle(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsToTitle]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsToTitle}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StringsToTitle); ok {
+		return fn(s)
+	}
 	return strings.ToTitle(s), nil
 }
 
@@ -460,6 +553,9 @@ func (ctx Strings) ToUpper(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsToUpper]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsToUpper}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StringsToUpper); ok {
+		return fn(s)
+	}
 	return strings.ToUpper(s), nil
 }
 
@@ -473,6 +569,9 @@ func (ctx Strings) ToValidUTF8(s, replacement string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsToValidUTF8]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsToValidUTF8}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StringsToValidUTF8); ok {
+		return fn(s, replacement)
+	}
 	return strings.ToValidUTF8(s, replacement), nil
 }
 
@@ -486,6 +585,9 @@ func (ctx Strings) Trim(s, cutset string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsTrim]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsTrim}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StringsTrim); ok {
+		return fn(s, cutset)
+	}
 	return strings.Trim(s, cutset), nil
 }
 
@@ -499,6 +601,9 @@ func (ctx Strings) TrimLeft(s, cutset string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsTrimLeft]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsTrimLeft}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StringsTrimLeft); ok {
+		return fn(s, cutset)
+	}
 	return strings.TrimLeft(s, cutset), nil
 }
 
@@ -512,6 +617,9 @@ func (ctx Strings) TrimPrefix(s, prefix string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsTrimPrefix]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsTrimPrefix}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StringsTrimPrefix); ok {
+		return fn(s, prefix)
+	}
 	return strings.TrimPrefix(s, prefix), nil
 }
 
@@ -525,6 +633,9 @@ func (ctx Strings) TrimRight(s, cutset string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsTrimRight]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsTrimRight}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StringsTrimRight); ok {
+		return fn(s, cutset)
+	}
 	return strings.TrimRight(s, cutset), nil
 }
 
@@ -538,6 +649,9 @@ func (ctx Strings) TrimSpace(s string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsTrimSpace]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsTrimSpace}
 	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.StringsTrimSpace); ok {
+		return fn(s)
+	}
 	return strings.TrimSpace(s), nil
 }
 
@@ -551,5 +665,8 @@ func (ctx Strings) TrimSuffix(s, prefix string) (string, error) {
 	if _, ok := ctx.allowedFunctionSet[funcs.StringsTrimSuffix]; !ok {
 		return "", &FuncNotAllowedError{Func: funcs.StringsTrimSuffix}
 	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.StringsTrimSuffix); ok {
+		return fn(s, prefix)
+	}
 	return strings.TrimSuffix(s, prefix), nil
 }
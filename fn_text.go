@@ -0,0 +1,446 @@
+package xtemplate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Text provides functions to lay out text: indenting, wrapping, truncating and padding.
+//
+// The text is the last argument, so the functions can be used in pipelines, e.g. with the output of tmpl.Exec:
+//
+//	{{ tmpl.Exec "partial" . | text.NIndent 4 }}
+//
+// Widths are measured in terminal columns: East Asian wide characters and most emoji count as two columns,
+// combining marks count as zero columns.
+type Text rootContext
+
+// maxTextWidth limits widths and indentation to keep the size of the results reasonable.
+const maxTextWidth = 1 << 16
+
+func checkTextWidth(width, lowest int) error {
+	if width < lowest || width > maxTextWidth {
+		return fmt.Errorf("%w: width %d", ErrInvalidArgument, width)
+	}
+	return nil
+}
+
+//nolint:gochecknoglobals // lookup table
+var wideRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f0, Stride: 1},
+		{Lo: 0x23f3, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x267f, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26ce, Stride: 1},
+		{Lo: 0x26d4, Hi: 0x26d4, Stride: 1},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26f5, Stride: 1},
+		{Lo: 0x26fa, Hi: 0x26fa, Stride: 1},
+		{Lo: 0x26fd, Hi: 0x26fd, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1},
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x18cff, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f320, Stride: 1},
+		{Lo: 0x1f32d, Hi: 0x1f335, Stride: 1},
+		{Lo: 0x1f337, Hi: 0x1f37c, Stride: 1},
+		{Lo: 0x1f37e, Hi: 0x1f393, Stride: 1},
+		{Lo: 0x1f3a0, Hi: 0x1f3ca, Stride: 1},
+		{Lo: 0x1f3cf, Hi: 0x1f3d3, Stride: 1},
+		{Lo: 0x1f3e0, Hi: 0x1f3f0, Stride: 1},
+		{Lo: 0x1f3f4, Hi: 0x1f3f4, Stride: 1},
+		{Lo: 0x1f3f8, Hi: 0x1f43e, Stride: 1},
+		{Lo: 0x1f440, Hi: 0x1f440, Stride: 1},
+		{Lo: 0x1f442, Hi: 0x1f4fc, Stride: 1},
+		{Lo: 0x1f4ff, Hi: 0x1f53d, Stride: 1},
+		{Lo: 0x1f54b, Hi: 0x1f54e, Stride: 1},
+		{Lo: 0x1f550, Hi: 0x1f567, Stride: 1},
+		{Lo: 0x1f57a, Hi: 0x1f57a, Stride: 1},
+		{Lo: 0x1f595, Hi: 0x1f596, Stride: 1},
+		{Lo: 0x1f5a4, Hi: 0x1f5a4, Stride: 1},
+		{Lo: 0x1f5fb, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6c5, Stride: 1},
+		{Lo: 0x1f6cc, Hi: 0x1f6cc, Stride: 1},
+		{Lo: 0x1f6d0, Hi: 0x1f6d2, Stride: 1},
+		{Lo: 0x1f6d5, Hi: 0x1f6d7, Stride: 1},
+		{Lo: 0x1f6eb, Hi: 0x1f6ec, Stride: 1},
+		{Lo: 0x1f6f4, Hi: 0x1f6fc, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f93a, Stride: 1},
+		{Lo: 0x1f93c, Hi: 0x1f945, Stride: 1},
+		{Lo: 0x1f947, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+	LatinOffset: 0,
+}
+
+// runeWidth returns the number of columns r occupies.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || r == '\u200b' || r == '\u200d' ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || (r >= 0xfe00 && r <= 0xfe0f):
+		return 0
+	case unicode.Is(wideRanges, r):
+		return 2 //nolint:mnd // wide characters occupy two columns
+	default:
+		return 1
+	}
+}
+
+func stringWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// Width returns the number of columns s occupies.
+//
+// Example:
+//
+//	{{ text.Width "日本語 abc" }} // Output: 10
+func (ctx Text) Width(s any) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextWidth]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.TextWidth}
+	}
+	if fn, ok := override[func(any) (int, error)](ctx.options, funcs.TextWidth); ok {
+		return fn(s)
+	}
+	return stringWidth(toString(s)), nil
+}
+
+func indent(n int, s string) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Indent indents every line of s by n spaces, empty lines are kept empty.
+//
+// Example:
+//
+//	{{ text.Indent 2 "a:\n  b: 1" | printf "%q" }} // Output: "  a:\n    b: 1"
+func (ctx Text) Indent(n int, s any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextIndent]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextIndent}
+	}
+	if fn, ok := override[func(int, any) (string, error)](ctx.options, funcs.TextIndent); ok {
+		return fn(n, s)
+	}
+	if err := checkTextWidth(n, 0); err != nil {
+		return "", err
+	}
+	return indent(n, toString(s)), nil
+}
+
+// NIndent is like Indent but starts with a new line, so it can follow a key on the same line.
+//
+// Example:
+//
+//	{{ text.NIndent 2 "replicas: 1" | printf "%q" }} // Output: "\n  replicas: 1"
+func (ctx Text) NIndent(n int, s any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextNIndent]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextNIndent}
+	}
+	if fn, ok := override[func(int, any) (string, error)](ctx.options, funcs.TextNIndent); ok {
+		return fn(n, s)
+	}
+	if err := checkTextWidth(n, 0); err != nil {
+		return "", err
+	}
+	return "\n" + indent(n, toString(s)), nil
+}
+
+// Dedent removes the leading whitespace that all non-blank lines of s have in common.
+// Lines that consist of whitespace only become empty.
+//
+// Example:
+//
+//	{{ text.Dedent "    a\n      b" | printf "%q" }} // Output: "a\n  b"
+func (ctx Text) Dedent(s any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextDedent]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextDedent}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.TextDedent); ok {
+		return fn(s)
+	}
+	lines := strings.Split(toString(s), "\n")
+	var prefix string
+	first := true
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		ws := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		switch {
+		case first:
+			prefix, first = ws, false
+		default:
+			for !strings.HasPrefix(ws, prefix) {
+				prefix = prefix[:len(prefix)-1]
+			}
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Wrap wraps the lines of s at word boundaries so that no line is wider than width columns.
+// Existing line breaks are kept, words that are wider than width are split.
+// The leading whitespace of a line is kept and repeated on its continuation lines, unless it leaves no room
+// for the text. Other runs of whitespace are replaced by a single space.
+//
+// Example:
+//
+//	{{ text.Wrap 10 "The quick brown fox jumps" | printf "%q" }} // Output: "The quick\nbrown fox\njumps"
+func (ctx Text) Wrap(width int, s any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextWrap]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextWrap}
+	}
+	if fn, ok := override[func(int, any) (string, error)](ctx.options, funcs.TextWrap); ok {
+		return fn(width, s)
+	}
+	if err := checkTextWidth(width, 1); err != nil {
+		return "", err
+	}
+	lines := strings.Split(toString(s), "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(width, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func wrapLine(width int, line string) string {
+	text := strings.TrimLeftFunc(line, unicode.IsSpace)
+	indent := line[:len(line)-len(text)]
+	if indentWidth := stringWidth(indent); indentWidth < width {
+		width -= indentWidth
+	} else {
+		indent = ""
+	}
+	var b strings.Builder
+	col := 0
+	for i, word := range strings.Fields(text) {
+		if i == 0 {
+			b.WriteString(indent)
+		}
+		w := stringWidth(word)
+		switch {
+		case col == 0:
+		case col+1+w <= width:
+			b.WriteByte(' ')
+			col++
+		default:
+			b.WriteString("\n" + indent)
+			col = 0
+		}
+		for w > width-col {
+			// split words that do not fit on a line
+			head, rest := cutWidth(word, width-col)
+			if head == "" {
+				// a single character is wider than the line
+				_, size := utf8.DecodeRuneInString(word)
+				head, rest = word[:size], word[size:]
+			}
+			b.WriteString(head)
+			if rest == "" {
+				col, word, w = stringWidth(head), "", 0
+				break
+			}
+			b.WriteString("\n" + indent)
+			col = 0
+			word, w = rest, stringWidth(rest)
+		}
+		b.WriteString(word)
+		col += w
+	}
+	return b.String()
+}
+
+// cutWidth splits s after at most width columns.
+func cutWidth(s string, width int) (head, tail string) {
+	w := 0
+	for i, r := range s {
+		rw := runeWidth(r)
+		if w+rw > width {
+			return s[:i], s[i:]
+		}
+		w += rw
+	}
+	return s, ""
+}
+
+// Truncate shortens s to at most width columns, a truncated string ends with the ellipsis.
+// The ellipsis defaults to "…".
+//
+// Example 1:
+//
+//	{{ text.Truncate 8 "Hello World" }} // Output: Hello W…
+//
+// Example 2:
+//
+//	{{ text.Truncate 8 "Hello World" "..." }} // Output: Hello...
+func (ctx Text) Truncate(width int, s any, ellipsis ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextTruncate]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextTruncate}
+	}
+	if fn, ok := override[func(int, any, ...string) (string, error)](ctx.options, funcs.TextTruncate); ok {
+		return fn(width, s, ellipsis...)
+	}
+	if len(ellipsis) > 1 {
+		return "", OnlyOneArgumentIsAllowedError{}
+	}
+	if err := checkTextWidth(width, 0); err != nil {
+		return "", err
+	}
+	str := toString(s)
+	if stringWidth(str) <= width {
+		return str, nil
+	}
+	e := "…"
+	if len(ellipsis) == 1 {
+		e = ellipsis[0]
+	}
+	e, _ = cutWidth(e, width)
+	head, _ := cutWidth(str, width-stringWidth(e))
+	return head + e, nil
+}
+
+// pad returns the padding for s to reach width, split into the left and right part.
+func pad(width int, s string, padding []string, left float64) (string, string, error) {
+	if len(padding) > 1 {
+		return "", "", OnlyOneArgumentIsAllowedError{}
+	}
+	if err := checkTextWidth(width, 0); err != nil {
+		return "", "", err
+	}
+	p := " "
+	if len(padding) == 1 {
+		p = padding[0]
+	}
+	if stringWidth(p) != 1 {
+		return "", "", fmt.Errorf("%w: padding %q must be one column wide", ErrInvalidArgument, p)
+	}
+	n := max(width-stringWidth(s), 0)
+	l := int(float64(n) * left)
+	return strings.Repeat(p, l), strings.Repeat(p, n-l), nil
+}
+
+// PadLeft pads s on the left to width columns, the padding defaults to a space.
+//
+// Example:
+//
+//	{{ text.PadLeft 5 "42" "0" }} // Output: 00042
+func (ctx Text) PadLeft(width int, s any, padding ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextPadLeft]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextPadLeft}
+	}
+	if fn, ok := override[func(int, any, ...string) (string, error)](ctx.options, funcs.TextPadLeft); ok {
+		return fn(width, s, padding...)
+	}
+	str := toString(s)
+	l, _, err := pad(width, str, padding, 1)
+	if err != nil {
+		return "", err
+	}
+	return l + str, nil
+}
+
+// PadRight pads s on the right to width columns, the padding defaults to a space.
+//
+// Example:
+//
+//	{{ text.PadRight 6 "日本" "." }} // Output: 日本..
+func (ctx Text) PadRight(width int, s any, padding ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextPadRight]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextPadRight}
+	}
+	if fn, ok := override[func(int, any, ...string) (string, error)](ctx.options, funcs.TextPadRight); ok {
+		return fn(width, s, padding...)
+	}
+	str := toString(s)
+	_, r, err := pad(width, str, padding, 0)
+	if err != nil {
+		return "", err
+	}
+	return str + r, nil
+}
+
+// Center pads s on both sides to width columns, the padding defaults to a space.
+// If the padding cannot be split evenly the right side gets one more column.
+//
+// Example:
+//
+//	{{ text.Center 9 "abc" "*" }} // Output: ***abc***
+func (ctx Text) Center(width int, s any, padding ...string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TextCenter]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TextCenter}
+	}
+	if fn, ok := override[func(int, any, ...string) (string, error)](ctx.options, funcs.TextCenter); ok {
+		return fn(width, s, padding...)
+	}
+	str := toString(s)
+	l, r, err := pad(width, str, padding, 0.5) //nolint:mnd // half of the padding
+	if err != nil {
+		return "", err
+	}
+	return l + str + r, nil
+}
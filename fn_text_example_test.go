@@ -0,0 +1,100 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleText_Center() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Center 9 "abc" "*" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: ***abc***
+}
+
+func ExampleText_Dedent() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Dedent "    a\n      b" | printf "%q" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "a\n  b"
+}
+
+func ExampleText_Indent() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Indent 2 "a:\n  b: 1" | printf "%q" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "  a:\n    b: 1"
+}
+
+func ExampleText_NIndent() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.NIndent 2 "replicas: 1" | printf "%q" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "\n  replicas: 1"
+}
+
+func ExampleText_PadLeft() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.PadLeft 5 "42" "0" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 00042
+}
+
+func ExampleText_PadRight() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.PadRight 6 "日本" "." }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 日本..
+}
+
+func ExampleText_Truncate() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Truncate 8 "Hello World" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello W…
+}
+
+func ExampleText_Truncate_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Truncate 8 "Hello World" "..." }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: Hello...
+}
+
+func ExampleText_Width() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Width "日本語 abc" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 10
+}
+
+func ExampleText_Wrap() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ text.Wrap 10 "The quick brown fox jumps" | printf "%q" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "The quick\nbrown fox\njumps"
+}
+
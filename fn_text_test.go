@@ -0,0 +1,98 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{name: "indent keeps empty lines", tmpl: `{{ text.Indent 2 . }}`, data: "a\n\nb\n", want: "  a\n\n  b\n"},
+		{
+			name: "nindent partial",
+			tmpl: `{{ define "spec" }}replicas: 1
+image: app{{ end }}spec:{{ tmpl.Exec "spec" | text.NIndent 2 }}`,
+			want: "spec:\n  replicas: 1\n  image: app",
+		},
+		{name: "dedent", tmpl: `{{ text.Dedent . }}`, data: "\n\t\ta\n\t\t  b\n\t\n\t\tc", want: "\na\n  b\n\nc"},
+		{name: "dedent mixed", tmpl: `{{ text.Dedent . }}`, data: "  a\n\tb", want: "  a\n\tb"},
+		{name: "wrap", tmpl: `{{ text.Wrap 7 . }}`, data: "aa bb cc\ndd  ee", want: "aa bb\ncc\ndd ee"},
+		{name: "wrap long word", tmpl: `{{ text.Wrap 4 . }}`, data: "a abcdefghij", want: "a\nabcd\nefgh\nij"},
+		{name: "wrap wide runes", tmpl: `{{ text.Wrap 5 . }}`, data: "日本語の文章", want: "日本\n語の\n文章"},
+		{name: "wrap wider than line", tmpl: `{{ text.Wrap 1 . }}`, data: "日本", want: "日\n本"},
+		{
+			name: "wrap indented",
+			tmpl: `{{ text.Wrap 10 . }}`,
+			data: "- item\n    aa bb  cc dd\n\tabcdefghijkl",
+			want: "- item\n    aa bb\n    cc dd\n\tabcdefghi\n\tjkl",
+		},
+		{
+			name: "wrap indentation wider than line",
+			tmpl: `{{ text.Wrap 2 . }}`,
+			data: "   ab cd",
+			want: "ab\ncd",
+		},
+		{name: "wrap blank line", tmpl: `{{ text.Wrap 5 . }}`, data: "a\n   \nb", want: "a\n\nb"},
+		{name: "truncate short", tmpl: `{{ text.Truncate 5 . }}`, data: "abc", want: "abc"},
+		{name: "truncate wide runes", tmpl: `{{ text.Truncate 6 . }}`, data: "日本語です", want: "日本…"},
+		{name: "truncate empty ellipsis", tmpl: `{{ text.Truncate 3 . "" }}`, data: "abcdef", want: "abc"},
+		{name: "truncate combining", tmpl: `{{ text.Truncate 3 . "." }}`, data: "e\u0301e\u0301e\u0301e", want: "e\u0301e\u0301."},
+		{name: "pad left", tmpl: `{{ text.PadLeft 4 . }}`, data: 7, want: "   7"},
+		{name: "pad right wide", tmpl: `{{ text.PadRight 5 . }}|`, data: "日本", want: "日本 |"},
+		{name: "pad no-op", tmpl: `{{ text.PadRight 2 . }}`, data: "abc", want: "abc"},
+		{name: "center", tmpl: `{{ text.Center 6 . "-" }}`, data: "ab", want: "--ab--"},
+		{name: "center uneven", tmpl: `{{ text.Center 5 . "-" }}`, data: "ab", want: "-ab--"},
+		{name: "width", tmpl: `{{ text.Width . }}`, data: "🙂 e\u0301", want: "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Text, funcs.Tmpl)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr error
+	}{
+		{name: "negative indent", tmpl: `{{ text.Indent -1 "a" }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{name: "zero wrap width", tmpl: `{{ text.Wrap 0 "a" }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{name: "width too large", tmpl: `{{ text.PadLeft 100000000 "a" }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{name: "wide padding", tmpl: `{{ text.Center 4 "a" "日" }}`, wantErr: xtemplate.ErrInvalidArgument},
+		{name: "multi rune padding", tmpl: `{{ text.PadLeft 4 "a" "ab" }}`, wantErr: xtemplate.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.Text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package xtemplate
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Time provides access to functions in the time package.
+//
+// Functions that accept a time accept time.Time values, RFC 3339 formatted strings and unix timestamps (seconds).
+// Functions that accept a duration accept time.Duration values, strings like "1h30m" and numbers (nanoseconds).
+// Layouts can be given as a layout string or as the name of a layout constant of the time package,
+// e.g. "RFC3339" or "DateOnly".
+type Time rootContext
+
+//nolint:gochecknoglobals // lookup table
+var timeLayouts = map[string]string{
+	"Layout":      time.Layout,
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+func timeLayout(layout string) string {
+	if l, ok := timeLayouts[layout]; ok {
+		return l
+	}
+	return layout
+}
+
+func toTime(v any) (time.Time, error) {
+	switch tv := v.(type) {
+	case time.Time:
+		return tv, nil
+	case TOMLLocalDate:
+		return tv.Time, nil
+	case TOMLLocalTime:
+		return tv.Time, nil
+	case TOMLLocalDateTime:
+		return tv.Time, nil
+	case *time.Time:
+		if tv == nil {
+			//nolint:err113 // allow dynamic error
+			return time.Time{}, fmt.Errorf("could not convert %v to time", v)
+		}
+		return *tv, nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, tv)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("could not convert %q to time: %w", tv, err)
+		}
+		return t, nil
+	}
+	sec, err := toInt64(v)
+	if err != nil {
+		//nolint:err113 // allow dynamic error
+		return time.Time{}, fmt.Errorf("could not convert %v to time", v)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+func toDuration(v any) (time.Duration, error) {
+	switch tv := v.(type) {
+	case time.Duration:
+		return tv, nil
+	case string:
+		d, err := time.ParseDuration(tv)
+		if err != nil {
+			return 0, fmt.Errorf("could not convert %q to duration: %w", tv, err)
+		}
+		return d, nil
+	}
+	if reflect.TypeOf(v) == reflect.TypeFor[bool]() {
+		//nolint:err113 // allow dynamic error
+		return 0, fmt.Errorf("could not convert %v to duration", v)
+	}
+	ns, err := toInt64(v)
+	if err != nil {
+		//nolint:err113 // allow dynamic error
+		return 0, fmt.Errorf("could not convert %v to duration", v)
+	}
+	return time.Duration(ns), nil
+}
+
+// Now returns the current time, as reported by the clock set with WithClock.
+// In deterministic mode Now fails unless a clock was set.
+//
+// Example:
+//
+//	{{ time.Now }}
+func (ctx Time) Now() (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeNow]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeNow}
+	}
+	if fn, ok := override[func() (time.Time, error)](ctx.options, funcs.TimeNow); ok {
+		return fn()
+	}
+	return ctx.options.now(funcs.TimeNow)
+}
+
+// Since returns the time elapsed since t, as reported by the clock set with WithClock.
+// In deterministic mode Since fails unless a clock was set.
+//
+// Example:
+//
+//	{{ time.Since "2006-01-02T15:04:05Z" }}
+func (ctx Time) Since(t any) (time.Duration, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeSince]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.TimeSince}
+	}
+	if fn, ok := override[func(any) (time.Duration, error)](ctx.options, funcs.TimeSince); ok {
+		return fn(t)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return 0, err
+	}
+	now, err := ctx.options.now(funcs.TimeSince)
+	if err != nil {
+		return 0, err
+	}
+	return now.Sub(tv), nil
+}
+
+// Parse parses a formatted string and returns the time value it represents.
+// The layout can be a layout string or the name of a layout constant.
+//
+// Example 1:
+//
+//	{{ time.Parse "2006-01-02" "2024-02-29" }} // Output: 2024-02-29 00:00:00 +0000 UTC
+//
+// Example 2:
+//
+//	{{ time.Parse "RFC3339" "2024-02-29T12:30:00Z" }} // Output: 2024-02-29 12:30:00 +0000 UTC
+func (ctx Time) Parse(layout, value string) (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeParse]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeParse}
+	}
+	if fn, ok := override[func(string, string) (time.Time, error)](ctx.options, funcs.TimeParse); ok {
+		return fn(layout, value)
+	}
+	return time.Parse(timeLayout(layout), value)
+}
+
+// Format returns a textual representation of the time value formatted according to the layout.
+// The layout can be a layout string or the name of a layout constant.
+//
+// Example 1:
+//
+//	{{ time.Format "2024-02-29T12:30:00Z" "Kitchen" }} // Output: 12:30PM
+//
+// Example 2:
+//
+//	{{ time.Format ( time.Unix 0 ) "2006-01-02" }} // Output: 1970-01-01
+func (ctx Time) Format(t any, layout string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeFormat]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TimeFormat}
+	}
+	if fn, ok := override[func(any, string) (string, error)](ctx.options, funcs.TimeFormat); ok {
+		return fn(t, layout)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return "", err
+	}
+	return tv.Format(timeLayout(layout)), nil
+}
+
+// Layout returns the layout string of a layout constant of the time package.
+//
+// Example:
+//
+//	{{ time.Layout "DateTime" }} // Output: 2006-01-02 15:04:05
+func (ctx Time) Layout(name string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeLayout]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TimeLayout}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.TimeLayout); ok {
+		return fn(name)
+	}
+	l, ok := timeLayouts[name]
+	if !ok {
+		//nolint:err113 // allow dynamic error
+		return "", fmt.Errorf("unknown layout %q", name)
+	}
+	return l, nil
+}
+
+// Unix returns the UTC time corresponding to the given unix time, sec seconds and nsec nanoseconds
+// since January 1, 1970 UTC.
+//
+// Example 1:
+//
+//	{{ time.Unix 1700000000 }} // Output: 2023-11-14 22:13:20 +0000 UTC
+//
+// Example 2:
+//
+//	{{ time.Unix 0 500 }} // Output: 1970-01-01 00:00:00.0000005 +0000 UTC
+func (ctx Time) Unix(sec any, nsec ...any) (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeUnix]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeUnix}
+	}
+	if fn, ok := override[func(any, ...any) (time.Time, error)](ctx.options, funcs.TimeUnix); ok {
+		return fn(sec, nsec...)
+	}
+	if len(nsec) > 1 {
+		return time.Time{}, OnlyOneArgumentIsAllowedError{}
+	}
+	s, err := toInt64(sec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var ns int64
+	if len(nsec) == 1 {
+		ns, err = toInt64(nsec[0])
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Unix(s, ns).UTC(), nil
+}
+
+// Add returns the time t+d.
+//
+// Example:
+//
+//	{{ time.Add "2024-02-29T12:30:00Z" "1h30m" }} // Output: 2024-02-29 14:00:00 +0000 UTC
+func (ctx Time) Add(t any, d any) (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeAdd]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeAdd}
+	}
+	if fn, ok := override[func(any, any) (time.Time, error)](ctx.options, funcs.TimeAdd); ok {
+		return fn(t, d)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	dv, err := toDuration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tv.Add(dv), nil
+}
+
+// AddDate returns the time corresponding to adding the given number of years, months, and days to t.
+//
+// Example:
+//
+//	{{ time.AddDate "2024-02-29T00:00:00Z" 1 0 0 }} // Output: 2025-03-01 00:00:00 +0000 UTC
+func (ctx Time) AddDate(t any, years, months, days int) (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeAddDate]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeAddDate}
+	}
+	if fn, ok := override[func(any, int, int, int) (time.Time, error)](ctx.options, funcs.TimeAddDate); ok {
+		return fn(t, years, months, days)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tv.AddDate(years, months, days), nil
+}
+
+// Sub returns the duration t-u.
+//
+// Example:
+//
+//	{{ time.Sub "2024-02-29T14:00:00Z" "2024-02-29T12:30:00Z" }} // Output: 1h30m0s
+func (ctx Time) Sub(t, u any) (time.Duration, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeSub]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.TimeSub}
+	}
+	if fn, ok := override[func(any, any) (time.Duration, error)](ctx.options, funcs.TimeSub); ok {
+		return fn(t, u)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return 0, err
+	}
+	uv, err := toTime(u)
+	if err != nil {
+		return 0, err
+	}
+	return tv.Sub(uv), nil
+}
+
+// Truncate returns the result of rounding t down to a multiple of d (since the zero time).
+//
+// Example:
+//
+//	{{ time.Truncate "2024-02-29T12:34:56Z" "1h" }} // Output: 2024-02-29 12:00:00 +0000 UTC
+func (ctx Time) Truncate(t any, d any) (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeTruncate]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeTruncate}
+	}
+	if fn, ok := override[func(any, any) (time.Time, error)](ctx.options, funcs.TimeTruncate); ok {
+		return fn(t, d)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	dv, err := toDuration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tv.Truncate(dv), nil
+}
+
+// In returns t in the time zone with the given name, e.g. "UTC" or "Europe/Berlin".
+// Time zones are loaded with the loader set by WithLocationLoader, or from the system's time zone database.
+// In deterministic mode only "UTC" is available unless a loader was set.
+//
+// Example:
+//
+//	{{ time.In "2024-02-29T12:30:00+01:00" "UTC" }} // Output: 2024-02-29 11:30:00 +0000 UTC
+func (ctx Time) In(t any, name string) (time.Time, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeIn]; !ok {
+		return time.Time{}, &FuncNotAllowedError{Func: funcs.TimeIn}
+	}
+	if fn, ok := override[func(any, string) (time.Time, error)](ctx.options, funcs.TimeIn); ok {
+		return fn(t, name)
+	}
+	tv, err := toTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := ctx.options.loadLocation(funcs.TimeIn, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tv.In(loc), nil
+}
+
+// ParseDuration parses a duration string, such as "300ms", "-1.5h" or "2h45m".
+// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+//
+// Example:
+//
+//	{{ time.ParseDuration "1h15m30.5s" }} // Output: 1h15m30.5s
+func (ctx Time) ParseDuration(s string) (time.Duration, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeParseDuration]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.TimeParseDuration}
+	}
+	if fn, ok := override[func(string) (time.Duration, error)](ctx.options, funcs.TimeParseDuration); ok {
+		return fn(s)
+	}
+	return time.ParseDuration(s)
+}
+
+// Before reports whether the time instant t is before u.
+//
+// Example:
+//
+//	{{ time.Before "2024-01-01T00:00:00Z" "2024-02-29T00:00:00Z" }} // Output: true
+func (ctx Time) Before(t, u any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeBefore]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.TimeBefore}
+	}
+	if fn, ok := override[func(any, any) (bool, error)](ctx.options, funcs.TimeBefore); ok {
+		return fn(t, u)
+	}
+	c, err := compareTimes(t, u)
+	return c < 0, err
+}
+
+// After reports whether the time instant t is after u.
+//
+// Example:
+//
+//	{{ time.After "2024-01-01T00:00:00Z" "2024-02-29T00:00:00Z" }} // Output: false
+func (ctx Time) After(t, u any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeAfter]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.TimeAfter}
+	}
+	if fn, ok := override[func(any, any) (bool, error)](ctx.options, funcs.TimeAfter); ok {
+		return fn(t, u)
+	}
+	c, err := compareTimes(t, u)
+	return c > 0, err
+}
+
+// Equal reports whether t and u represent the same time instant.
+//
+// Example:
+//
+//	{{ time.Equal "2024-02-29T12:30:00+01:00" "2024-02-29T11:30:00Z" }} // Output: true
+func (ctx Time) Equal(t, u any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeEqual]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.TimeEqual}
+	}
+	if fn, ok := override[func(any, any) (bool, error)](ctx.options, funcs.TimeEqual); ok {
+		return fn(t, u)
+	}
+	c, err := compareTimes(t, u)
+	return c == 0, err
+}
+
+// Compare compares the time instant t with u. If t is before u, it returns -1;
+// if t is after u, it returns +1; if they're the same, it returns 0.
+//
+// Example:
+//
+//	{{ time.Compare "2024-01-01T00:00:00Z" "2024-02-29T00:00:00Z" }} // Output: -1
+func (ctx Time) Compare(t, u any) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TimeCompare]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.TimeCompare}
+	}
+	if fn, ok := override[func(any, any) (int, error)](ctx.options, funcs.TimeCompare); ok {
+		return fn(t, u)
+	}
+	return compareTimes(t, u)
+}
+
+func compareTimes(t, u any) (int, error) {
+	tv, err := toTime(t)
+	if err != nil {
+		return 0, err
+	}
+	uv, err := toTime(u)
+	if err != nil {
+		return 0, err
+	}
+	return tv.Compare(uv), nil
+}
@@ -0,0 +1,163 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleTime_Add() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Add "2024-02-29T12:30:00Z" "1h30m" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2024-02-29 14:00:00 +0000 UTC
+}
+
+func ExampleTime_AddDate() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.AddDate "2024-02-29T00:00:00Z" 1 0 0 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2025-03-01 00:00:00 +0000 UTC
+}
+
+func ExampleTime_After() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.After "2024-01-01T00:00:00Z" "2024-02-29T00:00:00Z" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
+func ExampleTime_Before() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Before "2024-01-01T00:00:00Z" "2024-02-29T00:00:00Z" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleTime_Compare() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Compare "2024-01-01T00:00:00Z" "2024-02-29T00:00:00Z" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: -1
+}
+
+func ExampleTime_Equal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Equal "2024-02-29T12:30:00+01:00" "2024-02-29T11:30:00Z" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleTime_Format() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Format "2024-02-29T12:30:00Z" "Kitchen" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 12:30PM
+}
+
+func ExampleTime_Format_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Format ( time.Unix 0 ) "2006-01-02" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1970-01-01
+}
+
+func ExampleTime_In() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.In "2024-02-29T12:30:00+01:00" "UTC" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2024-02-29 11:30:00 +0000 UTC
+}
+
+func ExampleTime_Layout() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Layout "DateTime" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2006-01-02 15:04:05
+}
+
+func ExampleTime_Parse() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Parse "2006-01-02" "2024-02-29" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2024-02-29 00:00:00 +0000 UTC
+}
+
+func ExampleTime_Parse_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Parse "RFC3339" "2024-02-29T12:30:00Z" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2024-02-29 12:30:00 +0000 UTC
+}
+
+func ExampleTime_ParseDuration() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.ParseDuration "1h15m30.5s" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1h15m30.5s
+}
+
+func ExampleTime_Sub() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Sub "2024-02-29T14:00:00Z" "2024-02-29T12:30:00Z" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1h30m0s
+}
+
+func ExampleTime_Truncate() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Truncate "2024-02-29T12:34:56Z" "1h" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2024-02-29 12:00:00 +0000 UTC
+}
+
+func ExampleTime_Unix() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Unix 1700000000 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2023-11-14 22:13:20 +0000 UTC
+}
+
+func ExampleTime_Unix_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ time.Unix 0 500 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1970-01-01 00:00:00.0000005 +0000 UTC
+}
+
@@ -0,0 +1,183 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	leap := time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{name: "rfc3339 string", tmpl: `{{ time.Format . "Kitchen" }}`, data: "2024-02-29T12:30:00Z", want: "12:30PM"},
+		{
+			name: "rfc3339 nano string with zone",
+			tmpl: `{{ time.Format . "RFC3339Nano" }}`,
+			data: "2024-02-29T12:30:00.5+01:00",
+			want: "2024-02-29T12:30:00.5+01:00",
+		},
+		{name: "time value", tmpl: `{{ time.Format . "DateOnly" }}`, data: leap, want: "2024-02-29"},
+		{name: "time pointer", tmpl: `{{ time.Format . "DateOnly" }}`, data: &leap, want: "2024-02-29"},
+		{
+			name: "unix seconds",
+			tmpl: `{{ time.Format . "RFC3339" }}`,
+			data: int64(1700000000),
+			want: "2023-11-14T22:13:20Z",
+		},
+		{name: "unix seconds string", tmpl: `{{ time.Unix . }}`, data: "60", want: "1970-01-01 00:01:00 +0000 UTC"},
+		{name: "float seconds are truncated", tmpl: `{{ time.Format . "15:04:05" }}`, data: 90.9, want: "00:01:30"},
+		{
+			name: "unix float seconds are truncated",
+			tmpl: `{{ time.Unix . }}`,
+			data: 1.9,
+			want: "1970-01-01 00:00:01 +0000 UTC",
+		},
+		{
+			name: "unix nanoseconds",
+			tmpl: `{{ time.Unix 0 . }}`,
+			data: 1500,
+			want: "1970-01-01 00:00:00.0000015 +0000 UTC",
+		},
+		{
+			name: "layout constant",
+			tmpl: `{{ time.Parse "DateTime" . }}`,
+			data: "2024-02-29 12:30:00",
+			want: "2024-02-29 12:30:00 +0000 UTC",
+		},
+		{name: "layout string", tmpl: `{{ time.Layout . }}`, data: "TimeOnly", want: "15:04:05"},
+		{
+			name: "duration string",
+			tmpl: `{{ time.Add "2024-02-29T23:00:00Z" . }}`,
+			data: "90m",
+			want: "2024-03-01 00:30:00 +0000 UTC",
+		},
+		{
+			name: "duration nanoseconds",
+			tmpl: `{{ time.Add 0 . }}`,
+			data: 1500,
+			want: "1970-01-01 00:00:00.0000015 +0000 UTC",
+		},
+		{
+			name: "duration value",
+			tmpl: `{{ time.Truncate "2024-02-29T12:34:56Z" . }}`,
+			data: time.Hour,
+			want: "2024-02-29 12:00:00 +0000 UTC",
+		},
+		{
+			name: "add date",
+			tmpl: `{{ time.AddDate . 0 1 0 }}`,
+			data: "2024-01-31T00:00:00Z",
+			want: "2024-03-02 00:00:00 +0000 UTC",
+		},
+		{
+			name: "sub",
+			tmpl: `{{ time.Sub . "2024-02-29T12:00:00+01:00" }}`,
+			data: "2024-02-29T12:00:00Z",
+			want: "1h0m0s",
+		},
+		{
+			name: "compare across zones",
+			tmpl: `{{ time.Compare . "2024-02-29T11:30:00Z" }} {{ time.Compare . "2024-02-29T11:00:00Z" }}` +
+				` {{ time.Compare . "2024-02-29T12:00:00Z" }}`,
+			data: "2024-02-29T12:30:00+01:00",
+			want: "0 1 -1",
+		},
+		{
+			name: "before and after across zones",
+			tmpl: `{{ time.Before . "2024-02-29T12:00:00Z" }} {{ time.After . "2024-02-29T12:00:00Z" }}` +
+				` {{ time.Equal . "2024-02-29T12:30:00Z" }}`,
+			data: "2024-02-29T12:30:00+01:00",
+			want: "true false false",
+		},
+		{
+			name: "before and after equal instants",
+			tmpl: `{{ time.Before . "2024-02-29T11:30:00Z" }} {{ time.After . "2024-02-29T11:30:00Z" }}`,
+			data: "2024-02-29T12:30:00+01:00",
+			want: "false false",
+		},
+		{
+			name: "in utc",
+			tmpl: `{{ time.In . "UTC" }}`,
+			data: "2024-02-29T12:30:00-02:00",
+			want: "2024-02-29 14:30:00 +0000 UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.Time)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTime_Errors(t *testing.T) {
+	t.Parallel()
+
+	deterministic := xtemplate.WithDeterministic(xtemplate.Identity{})
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		options []xtemplate.AllowedFunctions
+		wantErr error
+	}{
+		{name: "bad layout", tmpl: `{{ time.Parse "2006-01-02" "29.02.2024" }}`, options: nil, wantErr: nil},
+		{name: "unknown layout constant", tmpl: `{{ time.Layout "RFC9999" }}`, options: nil, wantErr: nil},
+		{name: "bad duration", tmpl: `{{ time.ParseDuration "1 hour" }}`, options: nil, wantErr: nil},
+		{name: "bad duration argument", tmpl: `{{ time.Add 0 "soon" }}`, options: nil, wantErr: nil},
+		{name: "bool duration", tmpl: `{{ time.Add 0 true }}`, options: nil, wantErr: nil},
+		{name: "bad time string", tmpl: `{{ time.Format "2024-02-29" "DateOnly" }}`, options: nil, wantErr: nil},
+		{name: "bad time argument", tmpl: `{{ time.Before ( slice.New ) 0 }}`, options: nil, wantErr: nil},
+		{name: "too many unix arguments", tmpl: `{{ time.Unix 0 1 2 }}`, options: nil, wantErr: nil},
+		{name: "unknown location", tmpl: `{{ time.In 0 "Nowhere/Unknown" }}`, options: nil, wantErr: nil},
+		{
+			name:    "unknown location in deterministic mode",
+			tmpl:    `{{ time.In 0 "Nowhere/Unknown" }}`,
+			options: []xtemplate.AllowedFunctions{deterministic},
+			wantErr: &xtemplate.NondeterministicError{Func: funcs.TimeIn},
+		},
+		{
+			name:    "since in deterministic mode",
+			tmpl:    `{{ time.Since 0 }}`,
+			options: []xtemplate.AllowedFunctions{deterministic},
+			wantErr: &xtemplate.NondeterministicError{Func: funcs.TimeSince},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, append(tt.options, funcs.Time, funcs.Slice)...)
+			if err == nil {
+				t.Fatal("QuickExecute() expected an error")
+			}
+			var wantErr *xtemplate.NondeterministicError
+			if errors.As(tt.wantErr, &wantErr) {
+				var gotErr *xtemplate.NondeterministicError
+				if !errors.As(err, &gotErr) || *gotErr != *wantErr {
+					t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+
+	"github.com/Eun/xtemplate/funcs"
 )
 
 // OnlyOneArgumentIsAllowedError indicates that only one argument is allowed.
@@ -24,6 +26,12 @@ type Tmpl rootContext
 //	{{ $result := tmpl.Exec "T1" "World" }}
 //	Message: {{ $result }} // Output: Message: Hello World
 func (ctx Tmpl) Exec(name string, data ...any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TmplExec]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.TmplExec}
+	}
+	if fn, ok := override[func(string, ...any) (any, error)](ctx.options, funcs.TmplExec); ok {
+		return fn(name, data...)
+	}
 	var arg any
 	var buf bytes.Buffer
 	if len(data) > 1 {
@@ -0,0 +1,127 @@
+package xtemplate
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// TOML provides functions to encode and decode TOML documents.
+//
+// Decoded documents consist of map[any]any (with string keys), []any, string, int, float64, bool and time.Time
+// values. Local dates, local times and local date-times are decoded as TOMLLocalDate, TOMLLocalTime and
+// TOMLLocalDateTime, so they are encoded without an offset again.
+type TOML rootContext
+
+// tomlLocalTimeLayout is the layout of a TOML local time, fractional seconds are optional.
+const tomlLocalTimeLayout = "15:04:05.999999999"
+
+// TOMLLocalDate is a TOML date without a time and an offset, e.g. 1979-05-27.
+// The embedded time is midnight of the date in UTC.
+type TOMLLocalDate struct {
+	time.Time
+}
+
+// String returns the date in TOML format, e.g. "1979-05-27".
+func (d TOMLLocalDate) String() string {
+	return d.Format(time.DateOnly)
+}
+
+// MarshalJSON encodes the date as a JSON string in TOML format.
+func (d TOMLLocalDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// TOMLLocalTime is a TOML time of day without a date and an offset, e.g. 07:32:00.
+// The embedded time is on January 1, year 0, in UTC.
+type TOMLLocalTime struct {
+	time.Time
+}
+
+// String returns the time in TOML format, e.g. "07:32:00".
+func (t TOMLLocalTime) String() string {
+	return t.Format(tomlLocalTimeLayout)
+}
+
+// MarshalJSON encodes the time as a JSON string in TOML format.
+func (t TOMLLocalTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// TOMLLocalDateTime is a TOML date-time without an offset, e.g. 1979-05-27T07:32:00.
+// The embedded time is in UTC.
+type TOMLLocalDateTime struct {
+	time.Time
+}
+
+// String returns the date-time in TOML format, e.g. "1979-05-27T07:32:00".
+func (dt TOMLLocalDateTime) String() string {
+	return dt.Format("2006-01-02T" + tomlLocalTimeLayout)
+}
+
+// MarshalJSON encodes the date-time as a JSON string in TOML format.
+func (dt TOMLLocalDateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dt.String())
+}
+
+// Marshal returns the TOML encoding of the map v, keys are sorted.
+// Values come first, followed by tables and arrays of tables.
+// Nil values are omitted. Floats are always encoded as floats, e.g. 1.0, because TOML distinguishes
+// integers and floats.
+//
+// Example:
+//
+//	{{ toml.Marshal ( dict.New "name" "app" ) }} // Output: name = "app"
+func (ctx TOML) Marshal(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TOMLMarshal]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.TOMLMarshal}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.TOMLMarshal); ok {
+		return fn(v)
+	}
+	return marshalTOML(v)
+}
+
+// Unmarshal parses the TOML document in data (a string or []byte) and returns the result.
+//
+// Example:
+//
+//	{{ ( toml.Unmarshal "[server]\nports = [80, 443]" ).server.ports }} // Output: [80 443]
+func (ctx TOML) Unmarshal(data any) (map[any]any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TOMLUnmarshal]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.TOMLUnmarshal}
+	}
+	if fn, ok := override[func(any) (map[any]any, error)](ctx.options, funcs.TOMLUnmarshal); ok {
+		return fn(data)
+	}
+	b, err := toBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTOML(string(b))
+}
+
+// Valid reports whether data (a string or []byte) is a valid TOML document.
+//
+// Example 1:
+//
+//	{{ toml.Valid "name = \"app\"" }} // Output: true
+//
+// Example 2:
+//
+//	{{ toml.Valid "name = app" }} // Output: false
+func (ctx TOML) Valid(data any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.TOMLValid]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.TOMLValid}
+	}
+	if fn, ok := override[func(any) (bool, error)](ctx.options, funcs.TOMLValid); ok {
+		return fn(data)
+	}
+	b, err := toBytes(data)
+	if err != nil {
+		return false, err
+	}
+	_, err = unmarshalTOML(string(b))
+	return err == nil, nil
+}
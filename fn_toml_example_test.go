@@ -0,0 +1,46 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleTOML_Marshal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ toml.Marshal ( dict.New "name" "app" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: name = "app"
+}
+
+func ExampleTOML_Unmarshal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ ( toml.Unmarshal "[server]\nports = [80, 443]" ).server.ports }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [80 443]
+}
+
+func ExampleTOML_Valid() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ toml.Valid "name = \"app\"" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleTOML_Valid_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ toml.Valid "name = app" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
@@ -0,0 +1,340 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func unmarshalTOML(t *testing.T, data string) (any, error) {
+	t.Helper()
+	var got any
+	d := &yamlData{Data: data, got: &got}
+	_, err := xtemplate.QuickExecute(`{{ .Set ( toml.Unmarshal .Data ) }}`, d, funcs.TOML)
+	return got, err
+}
+
+func TestTOML_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want any
+	}{
+		{
+			name: "values",
+			data: `# comment
+int = +1_000
+hex = 0xDEAD_beef
+oct = 0o755
+bin = 0b1010
+float = -3.5e2
+inf = -inf
+bool = true
+str = "tab\there \u00e9"
+lit = 'C:\path'
+"quoted key" = 1
+a.b.c = "dotted"
+date = 1979-05-27
+local = 1979-05-27 07:32:00
+offset = 1979-05-27T00:32:00.5-07:00
+time = 07:32:00
+`,
+			want: map[any]any{
+				"int": 1000, "hex": 0xdeadbeef, "oct": 0o755, "bin": 10, "float": -350.0, "inf": math.Inf(-1),
+				"bool": true, "str": "tab\there é", "lit": `C:\path`, "quoted key": 1,
+				"a":     map[any]any{"b": map[any]any{"c": "dotted"}},
+				"date":  xtemplate.TOMLLocalDate{Time: time.Date(1979, 5, 27, 0, 0, 0, 0, time.UTC)},
+				"local": xtemplate.TOMLLocalDateTime{Time: time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC)},
+				"offset": time.Date(1979, 5, 27, 0, 32, 0, 500000000,
+					time.FixedZone("", -7*60*60)),
+				"time": xtemplate.TOMLLocalTime{Time: time.Date(0, 1, 1, 7, 32, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "multi-line strings",
+			data: "a = \"\"\"\nline one\nline \\\n    two\"\"\"\nb = '''\nraw \\n ''text'''''\n",
+			want: map[any]any{"a": "line one\nline two", "b": "raw \\n ''text''"},
+		},
+		{
+			name: "arrays and inline tables",
+			data: "a = [\n  1,\n  2, # comment\n]\nb = [[1], ['x', \"y\"]]\nc = { x = 1, y.z = 2 }\nd = []\n",
+			want: map[any]any{
+				"a": []any{1, 2},
+				"b": []any{[]any{1}, []any{"x", "y"}},
+				"c": map[any]any{"x": 1, "y": map[any]any{"z": 2}},
+				"d": []any{},
+			},
+		},
+		{
+			name: "tables and arrays of tables",
+			data: `title = "x"
+
+[server.http]
+port = 80
+
+[server]
+host = "localhost"
+
+[[products]]
+name = "a"
+
+[products.meta]
+tag = 1
+
+[[products]]
+name = "b"
+`,
+			want: map[any]any{
+				"title": "x",
+				"server": map[any]any{
+					"host": "localhost",
+					"http": map[any]any{"port": 80},
+				},
+				"products": []any{
+					map[any]any{"name": "a", "meta": map[any]any{"tag": 1}},
+					map[any]any{"name": "b"},
+				},
+			},
+		},
+		{
+			name: "empty",
+			data: "# only a comment\n",
+			want: map[any]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := unmarshalTOML(t, tt.data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOML_UnmarshalErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr xtemplate.SyntaxError
+	}{
+		{
+			name:    "invalid value",
+			data:    "a = 1\nb = yes\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 2, Column: 5, Msg: `invalid value "yes"`},
+		},
+		{
+			name:    "duplicate key",
+			data:    "a = 1\na = 2\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 2, Column: 6, Msg: "key a is already defined"},
+		},
+		{
+			name:    "duplicate table",
+			data:    "[a]\nx = 1\n[a]\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 3, Column: 4, Msg: "table a is already defined"},
+		},
+		{
+			name:    "table defined by dotted key",
+			data:    "a.b = 1\n[a]\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 2, Column: 4, Msg: "table a is already defined"},
+		},
+		{
+			name:    "extend inline table",
+			data:    "a = { x = 1 }\n[a.b]\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 2, Column: 6, Msg: "table a is already defined"},
+		},
+		{
+			name:    "array of tables on a value",
+			data:    "a = []\n[[a]]\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 2, Column: 6, Msg: "key a is not an array of tables"},
+		},
+		{
+			name:    "unterminated string",
+			data:    "a = \"abc\n",
+			wantErr: xtemplate.SyntaxError{Format: "toml", Line: 1, Column: 9, Msg: "unterminated string"},
+		},
+		{
+			name: "missing line break",
+			data: "a = 1 b = 2\n",
+			wantErr: xtemplate.SyntaxError{
+				Format: "toml",
+				Line:   1,
+				Column: 7,
+				Msg:    "expected the end of the line, got 'b'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := unmarshalTOML(t, tt.data)
+			var syntaxErr *xtemplate.SyntaxError
+			if !errors.As(err, &syntaxErr) || *syntaxErr != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, want %v", err, &tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTOML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "local date", data: "date = 1979-05-27\n"},
+		{name: "local time", data: "time = 07:32:00.999\n"},
+		{name: "local date-time", data: "local = 1979-05-27T07:32:00\n"},
+		{name: "offset date-time", data: "offset = 1979-05-27T00:32:00-07:00\n"},
+		{name: "utc date-time", data: "utc = 1979-05-27T07:32:00Z\n"},
+		{name: "integral float", data: "float = 1.0\n"},
+		{name: "large float", data: "float = 1e+100\n"},
+		{name: "integer", data: "int = 1\n"},
+		{name: "array", data: "dates = [1979-05-27, 07:32:00, 1.0]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(`{{ toml.Marshal ( toml.Unmarshal . ) }}`, tt.data, funcs.TOML)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.data {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestTOML_LocalValues(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `{{ $m := toml.Unmarshal . }}{{ $m.date }} {{ $m.time }} {{ $m.local }} ` +
+		`{{ $m.date.Year }} {{ time.Format $m.local "Kitchen" }} {{ fmt.Sprint $m.time }} ` +
+		`{{ conv.ToString ( json.Marshal $m ) }}`
+	got, err := xtemplate.QuickExecute(tmpl, "date = 1979-05-27\ntime = 07:32:00\nlocal = 1979-05-27 07:32:00.5\n",
+		funcs.Safe)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	want := `1979-05-27 07:32:00 1979-05-27T07:32:00.5 1979 7:32AM 07:32:00 ` +
+		`{"date":"1979-05-27","local":"1979-05-27T07:32:00.5","time":"07:32:00"}`
+	if got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+}
+
+func TestTOML_Dict(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `{{ $m := toml.Unmarshal . }}{{ dict.Keys $m }} {{ dict.HasKey $m.server "port" }} ` +
+		`{{ dict.HasValue ( index $m.products 0 ) "a" }} {{ dict.IsEmpty ( toml.Unmarshal "" ) }} ` +
+		`{{ toml.Marshal $m.server }}`
+	got, err := xtemplate.QuickExecute(tmpl, "[server]\nport = 80\n\n[[products]]\nname = \"a\"\n",
+		funcs.TOML, funcs.Dict, xtemplate.WithDeterministic(xtemplate.Identity{}))
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "[products server] true true true port = 80\n"; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+}
+
+func TestTOML_Marshal(t *testing.T) {
+	t.Parallel()
+
+	value := map[string]any{
+		"name":    "app",
+		"count":   8080,
+		"weight":  float64(2),
+		"ratio":   0.5,
+		"none":    nil,
+		"tags":    []any{"a", "b\"c"},
+		"created": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"server": map[string]any{
+			"host": "localhost",
+			"tls":  map[string]any{"enabled": true},
+		},
+		"only": map[string]any{"nested": map[string]any{"x": 1}},
+		"products": []any{
+			map[string]any{"name": "a", "dims": map[string]any{"w": 1}},
+			map[string]any{"name": "b"},
+		},
+		"dotted.key": map[string]any{},
+		"inline":     []any{map[string]any{"x": 1}, 2},
+	}
+	want := `count = 8080
+created = 2024-01-02T03:04:05Z
+inline = [{ x = 1 }, 2]
+name = "app"
+ratio = 0.5
+tags = ["a", "b\"c"]
+weight = 2.0
+
+["dotted.key"]
+
+[only.nested]
+x = 1
+
+[server]
+host = "localhost"
+
+[server.tls]
+enabled = true
+
+[[products]]
+name = "a"
+
+[products.dims]
+w = 1
+
+[[products]]
+name = "b"
+`
+
+	got, err := xtemplate.QuickExecute(`{{ toml.Marshal . }}`, value, funcs.TOML)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Marshal() = \n%s\nwant\n%s", got, want)
+	}
+
+	// the encoded document decodes to the same structure
+	roundTrip, err := unmarshalTOML(t, got)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	m, _ := roundTrip.(map[any]any)
+	if m["count"] != 8080 || m["weight"] != 2.0 || !reflect.DeepEqual(m["products"], []any{
+		map[any]any{"name": "a", "dims": map[any]any{"w": 1}},
+		map[any]any{"name": "b"},
+	}) {
+		t.Errorf("round trip = %#v", roundTrip)
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ toml.Marshal ( slice.New 1 2 ) }}`, nil, funcs.Safe)
+	if err == nil {
+		t.Error("Marshal() expected an error for a non map value")
+	}
+}
@@ -0,0 +1,107 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{
+			name: "parse",
+			tmpl: `{{ $u := url.Parse . }}{{ $u.Scheme }}|{{ $u.Host }}|{{ $u.Hostname }}|{{ $u.Port }}|` +
+				`{{ $u.Path }}|{{ $u.RawQuery }}|{{ $u.Fragment }}`,
+			data: "https://example.com:8443/a%20b/c?x=1&y=%2F#sec%201",
+			want: "https|example.com:8443|example.com|8443|/a b/c|x=1&y=%2F|sec 1",
+		},
+		{
+			name: "parse user",
+			tmpl: `{{ $u := url.Parse . }}{{ $u.User.Username }} {{ $u.User.Password }} {{ $u.User.PasswordSet }}`,
+			data: "ftp://me:s%40cret@[::1]:21/",
+			want: "me s@cret true",
+		},
+		{name: "parse ipv6 hostname", tmpl: `{{ (url.Parse .).Hostname }}`, data: "http://[::1]:80/", want: "::1"},
+		{name: "parse string", tmpl: `{{ url.Parse . }}`, data: "mailto:a@example.com", want: "mailto:a@example.com"},
+		{name: "query", tmpl: `{{ url.Query . }}`, data: "a=1&b=2&a=3&c", want: "map[a:[1 3] b:[2] c:[]]"},
+		{name: "query index", tmpl: `{{ index (url.Query .) "q" 0 }}`, data: "?q=a%26b", want: "a&b"},
+		{
+			name: "encode",
+			tmpl: `{{ url.Encode . }}`,
+			data: map[string]any{"b": []any{"x y", 2}, "a": "&", "n": nil, "e": []string{}},
+			want: "a=%26&b=x+y&b=2",
+		},
+		{
+			name: "encode query round trip",
+			tmpl: `{{ url.Encode (url.Query .) }}`,
+			data: "z=1&a=2&a=1",
+			want: "a=2&a=1&z=1",
+		},
+		{
+			name: "resolve absolute path",
+			tmpl: `{{ url.ResolveReference . "/x?y" }}`,
+			data: "https://example.com/a/b",
+			want: "https://example.com/x?y",
+		},
+		{
+			name: "resolve other host",
+			tmpl: `{{ url.ResolveReference (url.Parse .) "//cdn.example.com/lib.js" }}`,
+			data: "https://example.com/a/b",
+			want: "https://cdn.example.com/lib.js",
+		},
+		{
+			name: "set query chain",
+			tmpl: `{{ . | url.SetQuery (dict.New "page" 3 "sort" nil) | url.AddQuery (dict.New "tag" "new") }}`,
+			data: "https://example.com/list?tag=old&sort=asc&page=1#top",
+			want: "https://example.com/list?page=3&tag=old&tag=new#top",
+		},
+		{
+			name: "set query on parsed url",
+			tmpl: `{{ url.Parse . | url.SetQuery (dict.New "q" (slice.New "a b" "c")) }}`,
+			data: "https://example.com/search",
+			want: "https://example.com/search?q=a+b&q=c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.URL, funcs.Dict, funcs.Slice)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURL_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := xtemplate.QuickExecute(`{{ url.Encode "a=1" }}`, nil, funcs.URL)
+	if !errors.Is(err, xtemplate.ErrInvalidArgument) {
+		t.Errorf("Encode() error = %v, want %v", err, xtemplate.ErrInvalidArgument)
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ url.Parse "http://[::1" }}`, nil, funcs.URL)
+	if err == nil {
+		t.Error("Parse() expected an error for an invalid URL")
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ url.Query "a=%zz" }}`, nil, funcs.URL)
+	if err == nil {
+		t.Error("Query() expected an error for an invalid escape")
+	}
+}
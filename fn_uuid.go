@@ -0,0 +1,216 @@
+package xtemplate
+
+import (
+	"crypto/sha1" //nolint:gosec // required by RFC 9562 for version 5
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// UUID generates, parses and validates UUIDs as defined in RFC 9562.
+//
+// Random bytes are read from the source set with WithEntropy (crypto/rand by default), the current time is
+// taken from the clock set with WithClock.
+type UUID rootContext
+
+// ErrInvalidUUID is returned when a string is not a valid UUID.
+var ErrInvalidUUID = errors.New("invalid uuid")
+
+type uuid [16]byte
+
+//nolint:gochecknoglobals // lookup table
+var uuidNamespaces = map[string]uuid{
+	"dns":  {0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+	"url":  {0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+	"oid":  {0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+	"x500": {0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+}
+
+func (u uuid) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// setVersion sets the version and the RFC 9562 variant.
+func (u *uuid) setVersion(version byte) {
+	u[6] = (u[6] & 0x0f) | version<<4
+	u[8] = (u[8] & 0x3f) | 0x80
+}
+
+// parseUUID parses the canonical form, the form without hyphens, the braced form and the urn:uuid: form.
+func parseUUID(s string) (uuid, error) {
+	var u uuid
+	str := s
+	if len(str) == 45 && strings.EqualFold(str[:9], "urn:uuid:") {
+		str = str[9:]
+	} else if len(str) == 38 && str[0] == '{' && str[37] == '}' {
+		str = str[1:37]
+	}
+	switch len(str) {
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return u, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+		}
+		str = str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case 32:
+	default:
+		return u, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	if _, err := hex.Decode(u[:], []byte(str)); err != nil {
+		return u, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	return u, nil
+}
+
+// V4 returns a new random (version 4) UUID.
+//
+// Example:
+//
+//	{{ len uuid.V4 }} // Output: 36
+func (ctx UUID) V4() (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.UUIDV4]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.UUIDV4}
+	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.UUIDV4); ok {
+		return fn()
+	}
+	var u uuid
+	if err := ctx.options.readRandom(funcs.UUIDV4, u[:]); err != nil {
+		return "", err
+	}
+	u.setVersion(4) //nolint:mnd // version 4
+	return u.String(), nil
+}
+
+// V7 returns a new time-ordered (version 7) UUID, it starts with the current unix time in milliseconds.
+//
+// Example:
+//
+//	{{ len uuid.V7 }} // Output: 36
+func (ctx UUID) V7() (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.UUIDV7]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.UUIDV7}
+	}
+	if fn, ok := override[func() (string, error)](ctx.options, funcs.UUIDV7); ok {
+		return fn()
+	}
+	now, err := ctx.options.now(funcs.UUIDV7)
+	if err != nil {
+		return "", err
+	}
+	var u uuid
+	if err := ctx.options.readRandom(funcs.UUIDV7, u[6:]); err != nil {
+		return "", err
+	}
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(now.UnixMilli())) //nolint:gosec // dates before 1970 are not supported
+	copy(u[0:6], ts[2:])
+	u.setVersion(7) //nolint:mnd // version 7
+	return u.String(), nil
+}
+
+// V5 returns the name based (version 5, SHA-1) UUID of name in the given namespace.
+// The namespace is either a UUID or one of the predefined namespaces "dns", "url", "oid" and "x500".
+//
+// Example 1:
+//
+//	{{ uuid.V5 "dns" "example.com" }} // Output: cfbff0d1-9375-5685-968c-48ce8b15ae17
+//
+// Example 2:
+//
+//	{{ uuid.V5 "url" "https://example.com" }} // Output: 4fd35a71-71ef-5a55-a9d9-aa75c889a6d0
+func (ctx UUID) V5(namespace, name string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.UUIDV5]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.UUIDV5}
+	}
+	if fn, ok := override[func(string, string) (string, error)](ctx.options, funcs.UUIDV5); ok {
+		return fn(namespace, name)
+	}
+	ns, ok := uuidNamespaces[strings.ToLower(namespace)]
+	if !ok {
+		var err error
+		ns, err = parseUUID(namespace)
+		if err != nil {
+			return "", err
+		}
+	}
+	h := sha1.New() //nolint:gosec // see import
+	h.Write(ns[:])
+	h.Write([]byte(name))
+	var u uuid
+	copy(u[:], h.Sum(nil))
+	u.setVersion(5) //nolint:mnd // version 5
+	return u.String(), nil
+}
+
+// Parse parses s and returns it in the canonical form.
+// Besides the canonical form, the form without hyphens, the braced form and the urn:uuid: form are accepted.
+//
+// Example:
+//
+//	{{ uuid.Parse "{CFBFF0D1-9375-5685-968C-48CE8B15AE17}" }} // Output: cfbff0d1-9375-5685-968c-48ce8b15ae17
+func (ctx UUID) Parse(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.UUIDParse]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.UUIDParse}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.UUIDParse); ok {
+		return fn(s)
+	}
+	u, err := parseUUID(s)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Valid reports whether s is a valid UUID in one of the forms accepted by Parse.
+//
+// Example 1:
+//
+//	{{ uuid.Valid "cfbff0d1-9375-5685-968c-48ce8b15ae17" }} // Output: true
+//
+// Example 2:
+//
+//	{{ uuid.Valid "cfbff0d1-9375" }} // Output: false
+func (ctx UUID) Valid(s string) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.UUIDValid]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.UUIDValid}
+	}
+	if fn, ok := override[func(string) (bool, error)](ctx.options, funcs.UUIDValid); ok {
+		return fn(s)
+	}
+	_, err := parseUUID(s)
+	return err == nil, nil
+}
+
+// Version returns the version of the UUID s.
+//
+// Example:
+//
+//	{{ uuid.Version "cfbff0d1-9375-5685-968c-48ce8b15ae17" }} // Output: 5
+func (ctx UUID) Version(s string) (int, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.UUIDVersion]; !ok {
+		return 0, &FuncNotAllowedError{Func: funcs.UUIDVersion}
+	}
+	if fn, ok := override[func(string) (int, error)](ctx.options, funcs.UUIDVersion); ok {
+		return fn(s)
+	}
+	u, err := parseUUID(s)
+	if err != nil {
+		return 0, err
+	}
+	return int(u[6] >> 4), nil //nolint:mnd // version is stored in the high nibble
+}
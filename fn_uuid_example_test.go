@@ -0,0 +1,82 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleUUID_Parse() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ uuid.Parse "{CFBFF0D1-9375-5685-968C-48CE8B15AE17}" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: cfbff0d1-9375-5685-968c-48ce8b15ae17
+}
+
+func ExampleUUID_V4() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len uuid.V4 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 36
+}
+
+func ExampleUUID_V5() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ uuid.V5 "dns" "example.com" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: cfbff0d1-9375-5685-968c-48ce8b15ae17
+}
+
+func ExampleUUID_V5_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ uuid.V5 "url" "https://example.com" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 4fd35a71-71ef-5a55-a9d9-aa75c889a6d0
+}
+
+func ExampleUUID_V7() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len uuid.V7 }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 36
+}
+
+func ExampleUUID_Valid() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ uuid.Valid "cfbff0d1-9375-5685-968c-48ce8b15ae17" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleUUID_Valid_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ uuid.Valid "cfbff0d1-9375" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
+func ExampleUUID_Version() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ uuid.Version "cfbff0d1-9375-5685-968c-48ce8b15ae17" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 5
+}
+
@@ -0,0 +1,79 @@
+package xtemplate_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestUUID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reproducible", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := xtemplate.QuickExecute(`{{ uuid.V4 }} {{ uuid.V7 }}`, nil,
+			funcs.UUID,
+			xtemplate.WithEntropy(bytes.NewReader(bytes.Repeat([]byte{0xab}, 26))),
+			xtemplate.WithClock(func() time.Time { return time.UnixMilli(0x0123456789ab) }),
+		)
+		if err != nil {
+			t.Fatalf("QuickExecute() error = %v", err)
+		}
+		if want := "abababab-abab-4bab-abab-abababababab 01234567-89ab-7bab-abab-abababababab"; got != want {
+			t.Errorf("QuickExecute() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("version", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := xtemplate.QuickExecute(`{{ uuid.Version uuid.V4 }} {{ uuid.Version uuid.V7 }}`, nil, funcs.UUID)
+		if err != nil {
+			t.Fatalf("QuickExecute() error = %v", err)
+		}
+		if want := "4 7"; got != want {
+			t.Errorf("QuickExecute() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("forms", func(t *testing.T) {
+		t.Parallel()
+
+		tmpl := `{{ uuid.Parse "urn:uuid:cfbff0d1-9375-5685-968c-48ce8b15ae17" }} ` +
+			`{{ uuid.Parse "CFBFF0D193755685968C48CE8B15AE17" }}`
+		got, err := xtemplate.QuickExecute(tmpl, nil, funcs.UUID)
+		if err != nil {
+			t.Fatalf("QuickExecute() error = %v", err)
+		}
+		if want := "cfbff0d1-9375-5685-968c-48ce8b15ae17 cfbff0d1-9375-5685-968c-48ce8b15ae17"; got != want {
+			t.Errorf("QuickExecute() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xtemplate.QuickExecute(`{{ uuid.Parse "cfbff0d1-9375-5685-968c-48ce8b15ae1g" }}`, nil, funcs.UUID)
+		if !errors.Is(err, xtemplate.ErrInvalidUUID) {
+			t.Errorf("QuickExecute() error = %v, want %v", err, xtemplate.ErrInvalidUUID)
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xtemplate.QuickExecute(`{{ uuid.V4 }}`, nil,
+			funcs.UUID,
+			xtemplate.WithDeterministic(xtemplate.Identity{}),
+		)
+		var nondeterministicErr *xtemplate.NondeterministicError
+		if !errors.As(err, &nondeterministicErr) || nondeterministicErr.Func != funcs.UUIDV4 {
+			t.Errorf("QuickExecute() error = %v, want NondeterministicError", err)
+		}
+	})
+}
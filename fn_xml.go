@@ -0,0 +1,446 @@
+package xtemplate
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// XML provides functions to escape, encode, decode and query XML documents.
+//
+// Unmarshal decodes a document into a tree of *XMLElement values, names are kept as they are written
+// including their prefix (e.g. soap:Envelope). Marshal encodes dicts, slices and *XMLElement values.
+type XML rootContext
+
+// ErrInvalidXMLPath is returned when a query path is empty or contains empty segments.
+var ErrInvalidXMLPath = errors.New("invalid xml path")
+
+// InvalidXMLNameError is returned when an element or attribute name is not a valid XML name.
+type InvalidXMLNameError struct {
+	Name string
+}
+
+func (e *InvalidXMLNameError) Error() string {
+	return fmt.Sprintf("invalid xml name %q", e.Name)
+}
+
+// maxXMLDepth limits the nesting of elements.
+const maxXMLDepth = 1000
+
+// XMLElement is an element of a decoded XML document.
+// The document itself is an element without a name, its children are the root element.
+type XMLElement struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string // the character data of the element with leading and trailing whitespace removed
+	Children []*XMLElement
+}
+
+//nolint:gochecknoglobals // lookup table
+var xmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+// EscapeText escapes s for use as character data.
+//
+// Example:
+//
+//	{{ xml.EscapeText "a < b & c" }} // Output: a &lt; b &amp; c
+func (ctx XML) EscapeText(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLEscapeText]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.XMLEscapeText}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.XMLEscapeText); ok {
+		return fn(s)
+	}
+	return xmlTextEscaper.Replace(s), nil
+}
+
+// EscapeAttr escapes s for use as a quoted attribute value.
+//
+// Example:
+//
+//	{{ xml.EscapeAttr "say \"hi\"\n" }} // Output: say &#34;hi&#34;&#xA;
+func (ctx XML) EscapeAttr(s string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLEscapeAttr]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.XMLEscapeAttr}
+	}
+	if fn, ok := override[func(string) (string, error)](ctx.options, funcs.XMLEscapeAttr); ok {
+		return fn(s)
+	}
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Marshal returns v as XML elements called name.
+// Dict keys become child elements in sorted order, keys starting with @ become attributes and
+// the key #text becomes the character data. Slices become repeated elements.
+// *XMLElement values are written as they are.
+//
+// Example 1:
+//
+//	{{ xml.Marshal "dep" ( dict.New "@scope" "test" "id" "x" ) }} // Output: <dep scope="test"><id>x</id></dep>
+//
+// Example 2:
+//
+//	{{ xml.Marshal "id" ( slice.New 1 2 ) }} // Output: <id>1</id><id>2</id>
+func (ctx XML) Marshal(name string, v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLMarshal]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.XMLMarshal}
+	}
+	if fn, ok := override[func(string, any) (string, error)](ctx.options, funcs.XMLMarshal); ok {
+		return fn(name, v)
+	}
+	return marshalXML(name, v, "")
+}
+
+// MarshalIndent is like Marshal but indents nested elements with indent.
+//
+// Example:
+//
+//	{{ printf "%q" ( xml.MarshalIndent "a" ( dict.New "b" 1 ) "  " ) }} // Output: "<a>\n  <b>1</b>\n</a>"
+func (ctx XML) MarshalIndent(name string, v any, indent string) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLMarshalIndent]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.XMLMarshalIndent}
+	}
+	if fn, ok := override[func(string, any, string) (string, error)](ctx.options, funcs.XMLMarshalIndent); ok {
+		return fn(name, v, indent)
+	}
+	return marshalXML(name, v, indent)
+}
+
+// Unmarshal parses the XML document in data (a string or []byte) and returns the document element.
+// Comments, processing instructions and directives are skipped.
+//
+// Example:
+//
+//	{{ ( index ( xml.Unmarshal "<a id=\"1\">text</a>" ).Children 0 ).Attrs.id }} // Output: 1
+func (ctx XML) Unmarshal(data any) (*XMLElement, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLUnmarshal]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.XMLUnmarshal}
+	}
+	if fn, ok := override[func(any) (*XMLElement, error)](ctx.options, funcs.XMLUnmarshal); ok {
+		return fn(data)
+	}
+	b, err := toBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalXML(string(b))
+}
+
+// Query returns the elements below el that match path.
+// Path segments are separated by /, a segment matches elements with the same name, the same name
+// without prefix or any element for *.
+//
+// Example:
+//
+//	{{ range xml.Query ( xml.Unmarshal "<p><d><id>a</id></d><d/></p>" ) "p/*/id" }}{{ .Text }}{{ end }} // Output: a
+func (ctx XML) Query(el *XMLElement, path string) ([]*XMLElement, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLQuery]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.XMLQuery}
+	}
+	if fn, ok := override[func(*XMLElement, string) ([]*XMLElement, error)](ctx.options, funcs.XMLQuery); ok {
+		return fn(el, path)
+	}
+	return queryXML(el, path)
+}
+
+// QueryText returns the text of the elements below el that match path, see Query.
+//
+// Example:
+//
+//	{{ xml.QueryText ( xml.Unmarshal "<p><d><id>a</id></d><d><id>b</id></d></p>" ) "p/d/id" }} // Output: [a b]
+func (ctx XML) QueryText(el *XMLElement, path string) ([]string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.XMLQueryText]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.XMLQueryText}
+	}
+	if fn, ok := override[func(*XMLElement, string) ([]string, error)](ctx.options, funcs.XMLQueryText); ok {
+		return fn(el, path)
+	}
+	elements, err := queryXML(el, path)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(elements))
+	for i, e := range elements {
+		out[i] = e.Text
+	}
+	return out, nil
+}
+
+func queryXML(el *XMLElement, path string) ([]*XMLElement, error) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if slices.Contains(segments, "") {
+		return nil, ErrInvalidXMLPath
+	}
+	current := []*XMLElement{el}
+	for _, segment := range segments {
+		var next []*XMLElement
+		for _, e := range current {
+			if e == nil {
+				continue
+			}
+			for _, child := range e.Children {
+				if matchXMLName(child.Name, segment) {
+					next = append(next, child)
+				}
+			}
+		}
+		current = next
+	}
+	if current == nil {
+		return []*XMLElement{}, nil
+	}
+	return current, nil
+}
+
+func matchXMLName(name, segment string) bool {
+	if segment == "*" || name == segment {
+		return true
+	}
+	if strings.Contains(segment, ":") {
+		return false
+	}
+	_, local, ok := strings.Cut(name, ":")
+	return ok && local == segment
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// unmarshalXML decodes a document, RawToken keeps the prefixes so the element stack is checked here.
+//
+//nolint:cyclop // cannot be simplified
+func unmarshalXML(src string) (*XMLElement, error) {
+	d := xml.NewDecoder(strings.NewReader(src))
+	doc := &XMLElement{Name: "", Attrs: map[string]string{}, Text: "", Children: nil}
+	stack := []*XMLElement{doc}
+	texts := []*strings.Builder{{}}
+	syntaxError := func(msg string) error {
+		line, column := d.InputPos()
+		return &SyntaxError{Format: "xml", Line: line, Column: column, Msg: msg}
+	}
+	for {
+		tok, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var xmlErr *xml.SyntaxError
+			if errors.As(err, &xmlErr) {
+				return nil, syntaxError(xmlErr.Msg)
+			}
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 1 && len(doc.Children) > 0 {
+				return nil, syntaxError("multiple root elements")
+			}
+			if len(stack) > maxXMLDepth {
+				return nil, syntaxError(fmt.Sprintf("exceeded max depth of %d", maxXMLDepth))
+			}
+			el := &XMLElement{
+				Name:     xmlName(t.Name),
+				Attrs:    make(map[string]string, len(t.Attr)),
+				Text:     "",
+				Children: nil,
+			}
+			for _, attr := range t.Attr {
+				el.Attrs[xmlName(attr.Name)] = attr.Value
+			}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, el)
+			stack = append(stack, el)
+			texts = append(texts, &strings.Builder{})
+		case xml.EndElement:
+			el := stack[len(stack)-1]
+			if len(stack) == 1 || el.Name != xmlName(t.Name) {
+				return nil, syntaxError(fmt.Sprintf("unexpected end element </%s>", xmlName(t.Name)))
+			}
+			el.Text = strings.TrimSpace(texts[len(texts)-1].String())
+			stack = stack[:len(stack)-1]
+			texts = texts[:len(texts)-1]
+		case xml.CharData:
+			if len(stack) == 1 {
+				if strings.TrimSpace(string(t)) != "" {
+					return nil, syntaxError("character data outside of the root element")
+				}
+				continue
+			}
+			texts[len(texts)-1].Write(t)
+		}
+	}
+	if len(stack) > 1 {
+		return nil, syntaxError(fmt.Sprintf("element <%s> is not closed", stack[len(stack)-1].Name))
+	}
+	if len(doc.Children) == 0 {
+		return nil, syntaxError("missing root element")
+	}
+	return doc, nil
+}
+
+func isXMLName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r) || r == '_' || r == ':':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func marshalXML(name string, v any, indent string) (string, error) {
+	var b strings.Builder
+	e := xml.NewEncoder(&b)
+	e.Indent("", indent)
+	if err := encodeXML(e, name, v); err != nil {
+		return "", err
+	}
+	if err := e.Flush(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func encodeXMLElement(e *xml.Encoder, el *XMLElement) error {
+	if el == nil {
+		return nil
+	}
+	if el.Name == "" {
+		for _, child := range el.Children {
+			if err := encodeXMLElement(e, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !isXMLName(el.Name) {
+		return &InvalidXMLNameError{Name: el.Name}
+	}
+	start := xml.StartElement{Name: xml.Name{Space: "", Local: el.Name}, Attr: nil}
+	keys := make([]string, 0, len(el.Attrs))
+	for k := range el.Attrs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		if !isXMLName(k) {
+			return &InvalidXMLNameError{Name: k}
+		}
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: "", Local: k}, Value: el.Attrs[k]})
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if el.Text != "" {
+		if err := e.EncodeToken(xml.CharData(el.Text)); err != nil {
+			return err
+		}
+	}
+	for _, child := range el.Children {
+		if err := encodeXMLElement(e, child); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// encodeXML writes v as elements called name.
+//
+//nolint:cyclop, funlen // cannot be simplified
+func encodeXML(e *xml.Encoder, name string, v any) error {
+	switch el := v.(type) {
+	case *XMLElement:
+		return encodeXMLElement(e, el)
+	case XMLElement:
+		return encodeXMLElement(e, &el)
+	}
+	if !isXMLName(name) {
+		return &InvalidXMLNameError{Name: name}
+	}
+	rv, err := normalizeValue(v)
+	if err != nil {
+		return err
+	}
+	if s, ok := yamlString(rv); ok {
+		rv = reflect.ValueOf(s)
+	}
+	start := xml.StartElement{Name: xml.Name{Space: "", Local: name}, Attr: nil}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			if err := encodeXML(e, name, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		keys := make([]any, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.Interface())
+		}
+		sortAny(keys)
+		var text string
+		var children []any
+		for _, k := range keys {
+			key := toString(k)
+			switch {
+			case key == "#text":
+				text = toString(rv.MapIndex(reflect.ValueOf(k)).Interface())
+			case strings.HasPrefix(key, "@"):
+				if !isXMLName(key[1:]) {
+					return &InvalidXMLNameError{Name: key[1:]}
+				}
+				start.Attr = append(start.Attr, xml.Attr{
+					Name:  xml.Name{Space: "", Local: key[1:]},
+					Value: toString(rv.MapIndex(reflect.ValueOf(k)).Interface()),
+				})
+			default:
+				children = append(children, k)
+			}
+		}
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		if text != "" {
+			if err := e.EncodeToken(xml.CharData(text)); err != nil {
+				return err
+			}
+		}
+		for _, k := range children {
+			if err := encodeXML(e, toString(k), rv.MapIndex(reflect.ValueOf(k)).Interface()); err != nil {
+				return err
+			}
+		}
+		return e.EncodeToken(start.End())
+	default:
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		if rv.IsValid() {
+			if err := e.EncodeToken(xml.CharData(toString(rv.Interface()))); err != nil {
+				return err
+			}
+		}
+		return e.EncodeToken(start.End())
+	}
+}
@@ -0,0 +1,82 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleXML_EscapeAttr() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ xml.EscapeAttr "say \"hi\"\n" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: say &#34;hi&#34;&#xA;
+}
+
+func ExampleXML_EscapeText() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ xml.EscapeText "a < b & c" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a &lt; b &amp; c
+}
+
+func ExampleXML_Marshal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ xml.Marshal "dep" ( dict.New "@scope" "test" "id" "x" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: <dep scope="test"><id>x</id></dep>
+}
+
+func ExampleXML_Marshal_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ xml.Marshal "id" ( slice.New 1 2 ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: <id>1</id><id>2</id>
+}
+
+func ExampleXML_MarshalIndent() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ printf "%q" ( xml.MarshalIndent "a" ( dict.New "b" 1 ) "  " ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: "<a>\n  <b>1</b>\n</a>"
+}
+
+func ExampleXML_Query() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ range xml.Query ( xml.Unmarshal "<p><d><id>a</id></d><d/></p>" ) "p/*/id" }}{{ .Text }}{{ end }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: a
+}
+
+func ExampleXML_QueryText() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ xml.QueryText ( xml.Unmarshal "<p><d><id>a</id></d><d><id>b</id></d></p>" ) "p/d/id" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [a b]
+}
+
+func ExampleXML_Unmarshal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ ( index ( xml.Unmarshal "<a id=\"1\">text</a>" ).Children 0 ).Attrs.id }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 1
+}
+
@@ -0,0 +1,222 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+const testPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- a comment -->
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <artifactId>app</artifactId>
+  <dependencies>
+    <dependency scope="test">
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>lib &amp; more</artifactId>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+func TestXML_Query(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data string
+		want string
+	}{
+		{
+			name: "path",
+			tmpl: `{{ xml.QueryText ( xml.Unmarshal . ) "project/dependencies/dependency/artifactId" }}`,
+			data: testPOM,
+			want: "[junit lib & more]",
+		},
+		{
+			name: "leading slash and wildcard",
+			tmpl: `{{ xml.QueryText ( xml.Unmarshal . ) "/project/*/*/groupId" }}`,
+			data: testPOM,
+			want: "[junit org.example]",
+		},
+		{
+			name: "relative to an element",
+			tmpl: `{{ $dep := index ( xml.Query ( xml.Unmarshal . ) "project/dependencies/dependency" ) 0 }}` +
+				`{{ $dep.Attrs.scope }} {{ xml.QueryText $dep "groupId" }}`,
+			data: testPOM,
+			want: "test [junit]",
+		},
+		{
+			name: "no match",
+			tmpl: `{{ len ( xml.Query ( xml.Unmarshal . ) "project/missing" ) }}`,
+			data: testPOM,
+			want: "0",
+		},
+		{
+			name: "prefixed names",
+			tmpl: `{{ $doc := xml.Unmarshal . }}{{ xml.QueryText $doc "soap:Envelope/soap:Body/value" }}` +
+				`{{ xml.QueryText $doc "Envelope/Body/value" }}{{ len ( xml.Query $doc "x:Envelope" ) }}`,
+			data: `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">` +
+				`<soap:Body><value>1</value></soap:Body></soap:Envelope>`,
+			want: "[1][1]0",
+		},
+		{
+			name: "attributes",
+			tmpl: `{{ $root := index ( xml.Unmarshal . ).Children 0 }}{{ $root.Name }} {{ $root.Attrs }}`,
+			data: `<soap:Envelope xmlns:soap="urn:x" id="1"/>`,
+			want: "soap:Envelope map[id:1 xmlns:soap:urn:x]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.XML)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXML_UnmarshalErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr xtemplate.SyntaxError
+	}{
+		{
+			name:    "mismatched end element",
+			data:    "<a>\n  <b></c>\n</a>",
+			wantErr: xtemplate.SyntaxError{Format: "xml", Line: 2, Column: 10, Msg: "unexpected end element </c>"},
+		},
+		{
+			name:    "unclosed element",
+			data:    "<a><b></b>",
+			wantErr: xtemplate.SyntaxError{Format: "xml", Line: 1, Column: 11, Msg: "element <a> is not closed"},
+		},
+		{
+			name:    "multiple roots",
+			data:    "<a/>\n<b/>",
+			wantErr: xtemplate.SyntaxError{Format: "xml", Line: 2, Column: 5, Msg: "multiple root elements"},
+		},
+		{
+			name:    "empty",
+			data:    "<!-- nothing -->",
+			wantErr: xtemplate.SyntaxError{Format: "xml", Line: 1, Column: 17, Msg: "missing root element"},
+		},
+		{
+			name:    "invalid entity",
+			data:    "<a>&unknown;</a>",
+			wantErr: xtemplate.SyntaxError{
+				Format: "xml",
+				Line:   1,
+				Column: 13,
+				Msg:    "invalid character entity &unknown;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(`{{ xml.Unmarshal . }}`, tt.data, funcs.XML)
+			var syntaxErr *xtemplate.SyntaxError
+			if !errors.As(err, &syntaxErr) || *syntaxErr != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, want %v", err, &tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestXML_Marshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{
+			name: "dicts and slices",
+			tmpl: `{{ xml.MarshalIndent "project" . "  " }}`,
+			data: map[string]any{
+				"@xmlns":     "http://maven.apache.org/POM/4.0.0",
+				"artifactId": "app",
+				"dependencies": map[string]any{
+					"dependency": []any{
+						map[string]any{"artifactId": "a & b", "@scope": "test"},
+						map[string]any{"artifactId": "c", "optional": true},
+					},
+				},
+				"description": map[string]any{"#text": "x < y", "@lang": "en"},
+				"empty":       nil,
+			},
+			want: `<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <artifactId>app</artifactId>
+  <dependencies>
+    <dependency scope="test">
+      <artifactId>a &amp; b</artifactId>
+    </dependency>
+    <dependency>
+      <artifactId>c</artifactId>
+      <optional>true</optional>
+    </dependency>
+  </dependencies>
+  <description lang="en">x &lt; y</description>
+  <empty></empty>
+</project>`,
+		},
+		{
+			name: "round trip",
+			tmpl: `{{ xml.Marshal "" ( xml.Unmarshal . ) }}`,
+			data: `<s:a xmlns:s="urn:x" b="1"><c>text &amp; more</c><c/></s:a>`,
+			want: `<s:a b="1" xmlns:s="urn:x"><c>text &amp; more</c><c></c></s:a>`,
+		},
+		{
+			name: "element from a query",
+			tmpl: `{{ xml.Marshal "" ( index ( xml.Query ( xml.Unmarshal . ) "a/b" ) 1 ) }}`,
+			data: `<a><b>1</b><b x="y">2</b></a>`,
+			want: `<b x="y">2</b>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, tt.data, funcs.XML)
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() = \n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXML_MarshalInvalidName(t *testing.T) {
+	t.Parallel()
+
+	_, err := xtemplate.QuickExecute(`{{ xml.Marshal "a" . }}`, map[string]any{"not valid": 1}, funcs.XML)
+	var nameErr *xtemplate.InvalidXMLNameError
+	if !errors.As(err, &nameErr) || nameErr.Name != "not valid" {
+		t.Errorf("Marshal() error = %v, want InvalidXMLNameError", err)
+	}
+}
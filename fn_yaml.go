@@ -0,0 +1,144 @@
+package xtemplate
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// YAML provides functions to encode and decode YAML documents.
+//
+// Decoded documents consist of map[any]any (with string keys), []any, string, int, float64, bool and nil values.
+// Block and flow styles, anchors, aliases and merge keys (<<) are supported. Mapping keys are encoded in sorted order.
+type YAML rootContext
+
+// Marshal returns the YAML encoding of v in block style, map keys are sorted.
+//
+// Example:
+//
+//	{{ yaml.Marshal ( dict.New "name" "app" ) }} // Output: name: app
+func (ctx YAML) Marshal(v any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.YAMLMarshal]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.YAMLMarshal}
+	}
+	if fn, ok := override[func(any) (string, error)](ctx.options, funcs.YAMLMarshal); ok {
+		return fn(v)
+	}
+	return marshalYAML(v)
+}
+
+// Unmarshal parses the YAML document in data (a string or []byte) and returns the result.
+// Streams with more than one document must be split with Split first.
+//
+// Example:
+//
+//	{{ ( yaml.Unmarshal "name: app\nports: [80, 443]" ).ports }} // Output: [80 443]
+func (ctx YAML) Unmarshal(data any) (any, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.YAMLUnmarshal]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.YAMLUnmarshal}
+	}
+	if fn, ok := override[func(any) (any, error)](ctx.options, funcs.YAMLUnmarshal); ok {
+		return fn(data)
+	}
+	b, err := toBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalYAML(string(b))
+}
+
+// Valid reports whether data (a string or []byte) is a valid YAML document.
+//
+// Example 1:
+//
+//	{{ yaml.Valid "name: app" }} // Output: true
+//
+// Example 2:
+//
+//	{{ yaml.Valid "name: [app" }} // Output: false
+func (ctx YAML) Valid(data any) (bool, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.YAMLValid]; !ok {
+		return false, &FuncNotAllowedError{Func: funcs.YAMLValid}
+	}
+	if fn, ok := override[func(any) (bool, error)](ctx.options, funcs.YAMLValid); ok {
+		return fn(data)
+	}
+	b, err := toBytes(data)
+	if err != nil {
+		return false, err
+	}
+	_, err = unmarshalYAML(string(b))
+	return err == nil, nil
+}
+
+// Split splits a multi-document stream (a string or []byte) into its documents.
+//
+// Example:
+//
+//	{{ range yaml.Split "a: 1\n---\nb: 2\n" }}{{ yaml.Unmarshal . }}{{ end }} // Output: map[a:1]map[b:2]
+func (ctx YAML) Split(data any) ([]string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.YAMLSplit]; !ok {
+		return nil, &FuncNotAllowedError{Func: funcs.YAMLSplit}
+	}
+	if fn, ok := override[func(any) ([]string, error)](ctx.options, funcs.YAMLSplit); ok {
+		return fn(data)
+	}
+	b, err := toBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	docs := splitYAML(string(b))
+	out := make([]string, len(docs))
+	for i, doc := range docs {
+		out[i] = doc.text
+	}
+	return out, nil
+}
+
+// Join joins documents into a multi-document stream.
+// Strings and []byte values are used as they are, all other values are encoded with Marshal.
+// The documents can also be passed as a single slice.
+//
+// Example:
+//
+//	{{ len ( yaml.Split ( yaml.Join "a: 1" ( dict.New "b" 2 ) ) ) }} // Output: 2
+func (ctx YAML) Join(docs ...any) (string, error) {
+	if _, ok := ctx.allowedFunctionSet[funcs.YAMLJoin]; !ok {
+		return "", &FuncNotAllowedError{Func: funcs.YAMLJoin}
+	}
+	if fn, ok := override[func(...any) (string, error)](ctx.options, funcs.YAMLJoin); ok {
+		return fn(docs...)
+	}
+	if len(docs) == 1 {
+		if _, ok := docs[0].([]byte); !ok && reflect.ValueOf(docs[0]).Kind() == reflect.Slice {
+			var err error
+			if docs, err = sliceToAny(docs[0]); err != nil {
+				return "", err
+			}
+		}
+	}
+	var b strings.Builder
+	for i, doc := range docs {
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		var s string
+		switch v := doc.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			var err error
+			if s, err = marshalYAML(v); err != nil {
+				return "", err
+			}
+		}
+		b.WriteString(s)
+		if s != "" && !strings.HasSuffix(s, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,64 @@
+// Code generated by generate_examples.go; DO NOT EDIT.
+package xtemplate_test
+
+import (
+	"fmt"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func ExampleYAML_Join() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ len ( yaml.Split ( yaml.Join "a: 1" ( dict.New "b" 2 ) ) ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: 2
+}
+
+func ExampleYAML_Marshal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ yaml.Marshal ( dict.New "name" "app" ) }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: name: app
+}
+
+func ExampleYAML_Split() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ range yaml.Split "a: 1\n---\nb: 2\n" }}{{ yaml.Unmarshal . }}{{ end }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: map[a:1]map[b:2]
+}
+
+func ExampleYAML_Unmarshal() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ ( yaml.Unmarshal "name: app\nports: [80, 443]" ).ports }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: [80 443]
+}
+
+func ExampleYAML_Valid() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ yaml.Valid "name: app" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: true
+}
+
+func ExampleYAML_Valid_second() {
+	s, _ := xtemplate.QuickExecute(
+		`{{ yaml.Valid "name: [app" }}`,
+		nil,
+		funcs.All,
+	)
+	fmt.Println(s) // Output: false
+}
+
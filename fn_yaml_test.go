@@ -0,0 +1,347 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func unmarshalYAML(t *testing.T, data string) (any, error) {
+	t.Helper()
+	var got any
+	_, err := xtemplate.QuickExecute(`{{ .Set ( yaml.Unmarshal .Data ) }}`, &yamlData{Data: data, got: &got}, funcs.YAML)
+	return got, err
+}
+
+type yamlData struct {
+	Data string
+	got  *any
+}
+
+func (d *yamlData) Set(v any) string {
+	*d.got = v
+	return ""
+}
+
+func TestYAML_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want any
+	}{
+		{
+			name: "scalars",
+			data: "a: 1\nb: -2.5\nc: true\nd: ~\ne: hello world\nf: '007'\ng: 0x1F\nh: \"tab\\there\"\ni: 1e3\n",
+			want: map[any]any{
+				"a": 1, "b": -2.5, "c": true, "d": nil, "e": "hello world", "f": "007", "g": 31, "h": "tab\there",
+				"i": 1000.0,
+			},
+		},
+		{
+			name: "nested block collections",
+			data: `
+# comment
+server:
+  host: localhost   # trailing comment
+  ports:
+    - 80
+    - 443
+  tls:
+  - name: a
+    cert: x
+  - name: b
+empty:
+list:
+- - 1
+  - 2
+- []
+`,
+			want: map[any]any{
+				"server": map[any]any{
+					"host":  "localhost",
+					"ports": []any{80, 443},
+					"tls": []any{
+						map[any]any{"name": "a", "cert": "x"},
+						map[any]any{"name": "b"},
+					},
+				},
+				"empty": nil,
+				"list":  []any{[]any{1, 2}, []any{}},
+			},
+		},
+		{
+			name: "flow collections",
+			data: "a: {x: 1, y: [a, 'b', \"c\"], z: {}}\nb: [\n  1,\n  2,\n]\nc: [k: v]\nd: {\"json\":true}\n",
+			want: map[any]any{
+				"a": map[any]any{"x": 1, "y": []any{"a", "b", "c"}, "z": map[any]any{}},
+				"b": []any{1, 2},
+				"c": []any{map[any]any{"k": "v"}},
+				"d": map[any]any{"json": true},
+			},
+		},
+		{
+			name: "block scalars",
+			data: "literal: |\n  line 1\n    indented\n  line 3\n\nfolded: >\n  a\n  b\n\n  c\n" +
+				"strip: |-\n  x\nkeep: |+\n  y\n\nlast: end\n",
+			want: map[any]any{
+				"literal": "line 1\n  indented\nline 3\n",
+				"folded":  "a b\nc\n",
+				"strip":   "x",
+				"keep":    "y\n\n",
+				"last":    "end",
+			},
+		},
+		{
+			name: "multi-line scalars",
+			data: "plain: a\n  b\n\n  c\nquoted: \"x\n  y\\\n  z\"\n",
+			want: map[any]any{"plain": "a b\nc", "quoted": "x yz"},
+		},
+		{
+			name: "anchors and merge keys",
+			data: "base: &base\n  a: 1\n  b: 2\nderived:\n  <<: *base\n  b: 3\nlist: &l [1, 2]\ncopy: *l\n",
+			want: map[any]any{
+				"base":    map[any]any{"a": 1, "b": 2},
+				"derived": map[any]any{"a": 1, "b": 3},
+				"list":    []any{1, 2},
+				"copy":    []any{1, 2},
+			},
+		},
+		{
+			name: "tags",
+			data: "a: !!str 123\nb: !custom value\n",
+			want: map[any]any{"a": "123", "b": "value"},
+		},
+		{
+			name: "top level sequence",
+			data: "---\n- a\n- b: c\n  d: e\n...\n",
+			want: []any{"a", map[any]any{"b": "c", "d": "e"}},
+		},
+		{
+			name: "urls and colons",
+			data: "url: http://example.com:8080/path\ntime: 12:30\n",
+			want: map[any]any{"url": "http://example.com:8080/path", "time": "12:30"},
+		},
+		{
+			name: "empty",
+			data: "# only a comment\n",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := unmarshalYAML(t, tt.data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAML_UnmarshalErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr xtemplate.SyntaxError
+	}{
+		{
+			name:    "bad indentation",
+			data:    "a:\n  b:\n    c: 1\n   d: 2\n",
+			wantErr: xtemplate.SyntaxError{Format: "yaml", Line: 4, Column: 4, Msg: "bad indentation of a mapping entry"},
+		},
+		{
+			name:    "unterminated flow",
+			data:    "a: [1, 2\n",
+			wantErr: xtemplate.SyntaxError{Format: "yaml", Line: 2, Column: 1, Msg: "unterminated flow sequence"},
+		},
+		{
+			name:    "unknown anchor",
+			data:    "a: *missing\n",
+			wantErr: xtemplate.SyntaxError{Format: "yaml", Line: 1, Column: 12, Msg: `unknown anchor "missing"`},
+		},
+		{
+			name:    "duplicate key",
+			data:    "a: 1\na: 2\n",
+			wantErr: xtemplate.SyntaxError{Format: "yaml", Line: 2, Column: 2, Msg: `duplicate key "a"`},
+		},
+		{
+			name:    "nested mapping on one line",
+			data:    "a: b: c\n",
+			wantErr: xtemplate.SyntaxError{Format: "yaml", Line: 1, Column: 5, Msg: "mapping values are not allowed here"},
+		},
+		{
+			name: "multiple documents",
+			data: "a: 1\n---\nb: 2\n",
+			wantErr: xtemplate.SyntaxError{
+				Format: "yaml",
+				Line:   3,
+				Column: 1,
+				Msg:    "expected a single document, use yaml.Split for multi-document streams",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := unmarshalYAML(t, tt.data)
+			var syntaxErr *xtemplate.SyntaxError
+			if !errors.As(err, &syntaxErr) || *syntaxErr != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, want %v", err, &tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestYAML_BillionLaughs(t *testing.T) {
+	t.Parallel()
+
+	// every level refers to the previous one ten times, the last level expands to 10^9 nodes
+	var b strings.Builder
+	b.WriteString("l0: &l0 [lol]\n")
+	for i := 1; i <= 9; i++ {
+		fmt.Fprintf(&b, "l%d: &l%d [", i, i)
+		for j := range 10 {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "*l%d", i-1)
+		}
+		b.WriteString("]\n")
+	}
+
+	_, err := xtemplate.QuickExecute(`{{ yaml.Marshal ( yaml.Unmarshal . ) }}`, b.String(), funcs.YAML)
+	var syntaxErr *xtemplate.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Msg != "aliases expand to more than 100000 nodes" {
+		t.Fatalf("Unmarshal() error = %v, want an alias expansion error", err)
+	}
+
+	// documents that use aliases moderately still work
+	got, err := unmarshalYAML(t, "base: &base {a: 1, b: [1, 2]}\nx: *base\ny:\n  <<: *base\n  c: 3\n")
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	base := map[any]any{"a": 1, "b": []any{1, 2}}
+	want := map[any]any{"base": base, "x": base, "y": map[any]any{"a": 1, "b": []any{1, 2}, "c": 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %#v, want %#v", got, want)
+	}
+}
+
+func TestYAML_Dict(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `{{ $m := yaml.Unmarshal . }}{{ dict.Keys $m }} {{ dict.HasKey $m.server "port" }} ` +
+		`{{ dict.HasValue ( index $m.server.hosts 0 ) "a" }} {{ dict.IsEmpty ( yaml.Unmarshal "{}" ) }}`
+	got, err := xtemplate.QuickExecute(tmpl, "server:\n  port: 80\n  hosts:\n    - {name: a}\n",
+		funcs.YAML, funcs.Dict, xtemplate.WithDeterministic(xtemplate.Identity{}))
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "[server] true true true"; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+}
+
+func TestYAML_Marshal(t *testing.T) {
+	t.Parallel()
+
+	value := map[string]any{
+		"name":    "app",
+		"version": "1.0",
+		"enabled": true,
+		"ratio":   0.5,
+		"count":   float64(8080),
+		"empty":   map[string]any{},
+		"none":    nil,
+		"quoted":  []any{"yes", "true", "123", "- dash", "a: b", "", " padded", "#hash"},
+		"script":  "echo hello\necho world\n",
+		"servers": []any{
+			map[string]any{"host": "a", "ports": []int{80, 443}},
+			[]string{"x", "y"},
+		},
+		"special": map[string]any{"inf": math.Inf(1), "nan": math.NaN()},
+		"decimal": xtemplate.DecimalValue{},
+	}
+	want := `count: 8080
+decimal: 0
+empty: {}
+enabled: true
+name: app
+none: null
+quoted:
+  - "yes"
+  - "true"
+  - "123"
+  - "- dash"
+  - "a: b"
+  - ""
+  - " padded"
+  - "#hash"
+ratio: 0.5
+script: |
+  echo hello
+  echo world
+servers:
+  - host: a
+    ports:
+      - 80
+      - 443
+  - - x
+    - "y"
+special:
+  inf: .inf
+  nan: .nan
+version: "1.0"
+`
+
+	got, err := xtemplate.QuickExecute(`{{ yaml.Marshal . }}`, value, funcs.YAML)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Marshal() = \n%s\nwant\n%s", got, want)
+	}
+
+	// the encoded document decodes to the same value
+	delete(value, "special")
+	delete(value, "decimal")
+	roundTrip, err := unmarshalYAML(t, got)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	m, _ := roundTrip.(map[any]any)
+	if m["script"] != value["script"] || !reflect.DeepEqual(m["quoted"], value["quoted"]) ||
+		m["count"] != 8080 || m["version"] != "1.0" {
+		t.Errorf("round trip = %#v", roundTrip)
+	}
+}
+
+func TestYAML_SplitJoin(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `{{ $docs := yaml.Split .}}{{ len $docs }}|{{ yaml.Join $docs }}`
+	got, err := xtemplate.QuickExecute(tmpl, "%YAML 1.2\n---\na: 1\n--- # second\nb: 2\n...\n---\nc: 3\n", funcs.Safe)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "3|a: 1\n---\nb: 2\n---\nc: 3\n"; got != want {
+		t.Errorf("QuickExecute() = %q, want %q", got, want)
+	}
+}
@@ -20,6 +20,7 @@ import (
 type rootContext struct {
 	template           *template.Template
 	allowedFunctionSet map[funcs.Func]struct{}
+	options            *options
 }
 
 // FuncNotAllowedError is returned when a function is called that is not in the allowed function set.
@@ -50,11 +51,14 @@ func (e CustomError) Error() string {
 }
 
 // AllowedFunctions is an interface that types can implement to provide a list of allowed functions.
+// Options also implement this interface, so they can be passed along with the allowed functions.
 type AllowedFunctions interface {
 	Functions() []funcs.Func
 }
 
 // FuncMap returns a template.FuncMap containing only the functions specified in allowedFunctions.
+// The returned functions are bound to t, use an Engine when working with cloned template sets.
+// State such as the source of the rand namespace lives as long as the FuncMap, see WithRandSeed.
 //
 //nolint:cyclop, funlen // cannot be simplified
 func FuncMap(t *template.Template, allowedFunctions ...AllowedFunctions) template.FuncMap {
@@ -71,6 +75,13 @@ func FuncMap(t *template.Template, allowedFunctions ...AllowedFunctions) templat
 	rootCtx := rootContext{
 		template:           t,
 		allowedFunctionSet: allowedFunctionSet,
+		options:            newOptions(allowedFunctions),
+	}
+
+	if _, ok := allowedNamespaceSet["case"]; ok {
+		m["case"] = func(...any) (any, error) {
+			return Case(rootCtx), nil
+		}
 	}
 
 	if _, ok := allowedNamespaceSet["conv"]; ok {
@@ -85,24 +96,76 @@ func FuncMap(t *template.Template, allowedFunctions ...AllowedFunctions) templat
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["csv"]; ok {
+		m["csv"] = func(...any) (any, error) {
+			return CSV(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["decimal"]; ok {
+		m["decimal"] = func(...any) (any, error) {
+			return Decimal(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["dict"]; ok {
 		m["dict"] = func(...any) (any, error) {
 			return Dict(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["encoding"]; ok {
+		m["encoding"] = func(...any) (any, error) {
+			return Encoding(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["filepath"]; ok {
 		m["filepath"] = func(...any) (any, error) {
 			return FilePath(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["fmt"]; ok {
+		m["fmt"] = func(...any) (any, error) {
+			return Fmt(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["hash"]; ok {
+		m["hash"] = func(...any) (any, error) {
+			return Hash(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["html"]; ok {
+		m["html"] = func(args ...any) (any, error) {
+			// keep the builtin html function working, e.g. {{ .x | html }}
+			if len(args) > 0 {
+				return template.HTMLEscaper(args...), nil
+			}
+			return htmlNamespace{HTML: HTML(rootCtx)}, nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["json"]; ok {
 		m["json"] = func(...any) (any, error) {
 			return JSON(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["math"]; ok {
+		m["math"] = func(...any) (any, error) {
+			return Math(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["net"]; ok {
+		m["net"] = func(...any) (any, error) {
+			return Net(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["os"]; ok {
 		m["os"] = func(...any) (any, error) {
 			return OS(rootCtx), nil
@@ -115,35 +178,89 @@ func FuncMap(t *template.Template, allowedFunctions ...AllowedFunctions) templat
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["rand"]; ok {
+		m["rand"] = func(...any) (any, error) {
+			return Rand(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["regexp"]; ok {
 		m["regexp"] = func(...any) (any, error) {
 			return Regexp(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["semver"]; ok {
+		m["semver"] = func(...any) (any, error) {
+			return Semver(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["slice"]; ok {
 		m["slice"] = func(...any) (any, error) {
 			return Slice(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["strconv"]; ok {
+		m["strconv"] = func(...any) (any, error) {
+			return Strconv(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["strings"]; ok {
 		m["strings"] = func(...any) (any, error) {
 			return Strings(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["text"]; ok {
+		m["text"] = func(...any) (any, error) {
+			return Text(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["time"]; ok {
+		m["time"] = func(...any) (any, error) {
+			return Time(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["tmpl"]; ok {
 		m["tmpl"] = func(...any) (any, error) {
 			return Tmpl(rootCtx), nil
 		}
 	}
 
+	if _, ok := allowedNamespaceSet["toml"]; ok {
+		m["toml"] = func(...any) (any, error) {
+			return TOML(rootCtx), nil
+		}
+	}
+
 	if _, ok := allowedNamespaceSet["url"]; ok {
 		m["url"] = func(...any) (any, error) {
 			return URL(rootCtx), nil
 		}
 	}
+
+	if _, ok := allowedNamespaceSet["uuid"]; ok {
+		m["uuid"] = func(...any) (any, error) {
+			return UUID(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["xml"]; ok {
+		m["xml"] = func(...any) (any, error) {
+			return XML(rootCtx), nil
+		}
+	}
+
+	if _, ok := allowedNamespaceSet["yaml"]; ok {
+		m["yaml"] = func(...any) (any, error) {
+			return YAML(rootCtx), nil
+		}
+	}
 	return m
 }
 
@@ -4,15 +4,33 @@ import "slices"
 
 // Safe is the set of functions that are considered safe for use in untrusted templates.
 var Safe = slices.Concat(
+	Case,
 	Cmp,
 	Conv,
+	CSV,
+	Decimal,
 	Dict,
+	Encoding,
 	FilePath,
+	Fmt,
+	Hash,
+	HTML,
 	JSON,
+	Math,
+	Net,
 	Path,
+	Rand,
 	Regexp,
+	Semver,
 	Slice,
+	Strconv,
 	Strings,
+	Text,
+	Time,
 	Tmpl,
+	TOML,
 	URL,
+	UUID,
+	XML,
+	YAML,
 )
@@ -4,6 +4,17 @@ package funcs
 
 // Methods
 var (
+	CSVEncode = Func { "csv", "Encode" }
+	CSVParse = Func { "csv", "Parse" }
+	CSVParseWithHeader = Func { "csv", "ParseWithHeader" }
+	CaseCamel = Func { "case", "Camel" }
+	CaseKebab = Func { "case", "Kebab" }
+	CasePascal = Func { "case", "Pascal" }
+	CaseScreamingSnake = Func { "case", "ScreamingSnake" }
+	CaseSentence = Func { "case", "Sentence" }
+	CaseSnake = Func { "case", "Snake" }
+	CaseSplit = Func { "case", "Split" }
+	CaseTitle = Func { "case", "Title" }
 	CmpOr = Func { "cmp", "Or" }
 	ConvToBool = Func { "conv", "ToBool" }
 	ConvToBools = Func { "conv", "ToBools" }
@@ -33,20 +44,67 @@ var (
 	ConvToUint8 = Func { "conv", "ToUint8" }
 	ConvToUint8s = Func { "conv", "ToUint8s" }
 	ConvToUints = Func { "conv", "ToUints" }
+	DecimalAdd = Func { "decimal", "Add" }
+	DecimalCmp = Func { "decimal", "Cmp" }
+	DecimalDiv = Func { "decimal", "Div" }
+	DecimalEqual = Func { "decimal", "Equal" }
+	DecimalFormat = Func { "decimal", "Format" }
+	DecimalMul = Func { "decimal", "Mul" }
+	DecimalNew = Func { "decimal", "New" }
+	DecimalRound = Func { "decimal", "Round" }
+	DecimalSub = Func { "decimal", "Sub" }
+	DecimalSum = Func { "decimal", "Sum" }
 	DictHasKey = Func { "dict", "HasKey" }
 	DictHasValue = Func { "dict", "HasValue" }
 	DictIsEmpty = Func { "dict", "IsEmpty" }
 	DictKeys = Func { "dict", "Keys" }
 	DictNew = Func { "dict", "New" }
+	EncodingASCII85Decode = Func { "encoding", "ASCII85Decode" }
+	EncodingASCII85Encode = Func { "encoding", "ASCII85Encode" }
+	EncodingBase32Decode = Func { "encoding", "Base32Decode" }
+	EncodingBase32Encode = Func { "encoding", "Base32Encode" }
+	EncodingBase64Decode = Func { "encoding", "Base64Decode" }
+	EncodingBase64Encode = Func { "encoding", "Base64Encode" }
+	EncodingBase64RawDecode = Func { "encoding", "Base64RawDecode" }
+	EncodingBase64RawEncode = Func { "encoding", "Base64RawEncode" }
+	EncodingBase64RawURLDecode = Func { "encoding", "Base64RawURLDecode" }
+	EncodingBase64RawURLEncode = Func { "encoding", "Base64RawURLEncode" }
+	EncodingBase64URLDecode = Func { "encoding", "Base64URLDecode" }
+	EncodingBase64URLEncode = Func { "encoding", "Base64URLEncode" }
+	EncodingHexDecode = Func { "encoding", "HexDecode" }
+	EncodingHexEncode = Func { "encoding", "HexEncode" }
 	FilePathAbs = Func { "filepath", "Abs" }
 	FilePathBase = Func { "filepath", "Base" }
 	FilePathClean = Func { "filepath", "Clean" }
 	FilePathDir = Func { "filepath", "Dir" }
 	FilePathExt = Func { "filepath", "Ext" }
 	FilePathFromSlash = Func { "filepath", "FromSlash" }
+	FilePathGlob = Func { "filepath", "Glob" }
+	FilePathIsAbs = Func { "filepath", "IsAbs" }
+	FilePathIsLocal = Func { "filepath", "IsLocal" }
 	FilePathJoin = Func { "filepath", "Join" }
+	FilePathLocalize = Func { "filepath", "Localize" }
+	FilePathMatch = Func { "filepath", "Match" }
 	FilePathRel = Func { "filepath", "Rel" }
+	FilePathSplit = Func { "filepath", "Split" }
+	FilePathSplitList = Func { "filepath", "SplitList" }
 	FilePathToSlash = Func { "filepath", "ToSlash" }
+	FilePathVolumeName = Func { "filepath", "VolumeName" }
+	FmtSprint = Func { "fmt", "Sprint" }
+	FmtSprintf = Func { "fmt", "Sprintf" }
+	FmtSprintln = Func { "fmt", "Sprintln" }
+	HTMLAttr = Func { "html", "Attr" }
+	HTMLEscapeString = Func { "html", "EscapeString" }
+	HTMLStripTags = Func { "html", "StripTags" }
+	HTMLUnescapeString = Func { "html", "UnescapeString" }
+	HashCRC32 = Func { "hash", "CRC32" }
+	HashFNV32a = Func { "hash", "FNV32a" }
+	HashFNV64a = Func { "hash", "FNV64a" }
+	HashHMACSHA256 = Func { "hash", "HMACSHA256" }
+	HashMD5 = Func { "hash", "MD5" }
+	HashSHA1 = Func { "hash", "SHA1" }
+	HashSHA256 = Func { "hash", "SHA256" }
+	HashSHA512 = Func { "hash", "SHA512" }
 	JSONCompact = Func { "json", "Compact" }
 	JSONHTMLEscape = Func { "json", "HTMLEscape" }
 	JSONIndent = Func { "json", "Indent" }
@@ -54,6 +112,32 @@ var (
 	JSONMarshalIndent = Func { "json", "MarshalIndent" }
 	JSONUnmarshal = Func { "json", "Unmarshal" }
 	JSONValid = Func { "json", "Valid" }
+	MathAbs = Func { "math", "Abs" }
+	MathAdd = Func { "math", "Add" }
+	MathCeil = Func { "math", "Ceil" }
+	MathClamp = Func { "math", "Clamp" }
+	MathDiv = Func { "math", "Div" }
+	MathFloor = Func { "math", "Floor" }
+	MathMax = Func { "math", "Max" }
+	MathMin = Func { "math", "Min" }
+	MathMod = Func { "math", "Mod" }
+	MathMul = Func { "math", "Mul" }
+	MathPow = Func { "math", "Pow" }
+	MathRound = Func { "math", "Round" }
+	MathSqrt = Func { "math", "Sqrt" }
+	MathSub = Func { "math", "Sub" }
+	MathSum = Func { "math", "Sum" }
+	NetContains = Func { "net", "Contains" }
+	NetHost = Func { "net", "Host" }
+	NetIsIPv4 = Func { "net", "IsIPv4" }
+	NetIsIPv6 = Func { "net", "IsIPv6" }
+	NetJoinHostPort = Func { "net", "JoinHostPort" }
+	NetNext = Func { "net", "Next" }
+	NetParseAddr = Func { "net", "ParseAddr" }
+	NetParsePrefix = Func { "net", "ParsePrefix" }
+	NetPrev = Func { "net", "Prev" }
+	NetSplitHostPort = Func { "net", "SplitHostPort" }
+	NetSubnet = Func { "net", "Subnet" }
 	OSChdir = Func { "os", "Chdir" }
 	OSChmod = Func { "os", "Chmod" }
 	OSChown = Func { "os", "Chown" }
@@ -107,7 +191,18 @@ var (
 	PathClean = Func { "path", "Clean" }
 	PathDir = Func { "path", "Dir" }
 	PathExt = Func { "path", "Ext" }
+	PathIsAbs = Func { "path", "IsAbs" }
 	PathJoin = Func { "path", "Join" }
+	PathMatch = Func { "path", "Match" }
+	PathSplit = Func { "path", "Split" }
+	RandChoice = Func { "rand", "Choice" }
+	RandCryptoString = Func { "rand", "CryptoString" }
+	RandFloat = Func { "rand", "Float" }
+	RandInt = Func { "rand", "Int" }
+	RandIntN = Func { "rand", "IntN" }
+	RandSample = Func { "rand", "Sample" }
+	RandShuffle = Func { "rand", "Shuffle" }
+	RandString = Func { "rand", "String" }
 	RegexpFindAllString = Func { "regexp", "FindAllString" }
 	RegexpFindAllStringIndex = Func { "regexp", "FindAllStringIndex" }
 	RegexpFindAllStringSubmatch = Func { "regexp", "FindAllStringSubmatch" }
@@ -121,6 +216,14 @@ var (
 	RegexpReplaceAllLiteralString = Func { "regexp", "ReplaceAllLiteralString" }
 	RegexpReplaceAllString = Func { "regexp", "ReplaceAllString" }
 	RegexpSplit = Func { "regexp", "Split" }
+	SemverBumpMajor = Func { "semver", "BumpMajor" }
+	SemverBumpMinor = Func { "semver", "BumpMinor" }
+	SemverBumpPatch = Func { "semver", "BumpPatch" }
+	SemverCompare = Func { "semver", "Compare" }
+	SemverParse = Func { "semver", "Parse" }
+	SemverSatisfies = Func { "semver", "Satisfies" }
+	SemverSort = Func { "semver", "Sort" }
+	SemverValid = Func { "semver", "Valid" }
 	SliceAppend = Func { "slice", "Append" }
 	SliceCompact = Func { "slice", "Compact" }
 	SliceContains = Func { "slice", "Contains" }
@@ -136,6 +239,17 @@ var (
 	SliceReverse = Func { "slice", "Reverse" }
 	SliceSort = Func { "slice", "Sort" }
 	SliceUnique = Func { "slice", "Unique" }
+	StrconvAppendQuote = Func { "strconv", "AppendQuote" }
+	StrconvAppendQuoteJSON = Func { "strconv", "AppendQuoteJSON" }
+	StrconvAppendQuoteToASCII = Func { "strconv", "AppendQuoteToASCII" }
+	StrconvCanBackquote = Func { "strconv", "CanBackquote" }
+	StrconvFormatFloat = Func { "strconv", "FormatFloat" }
+	StrconvFormatInt = Func { "strconv", "FormatInt" }
+	StrconvParseInt = Func { "strconv", "ParseInt" }
+	StrconvQuote = Func { "strconv", "Quote" }
+	StrconvQuoteJSON = Func { "strconv", "QuoteJSON" }
+	StrconvQuoteToASCII = Func { "strconv", "QuoteToASCII" }
+	StrconvUnquote = Func { "strconv", "Unquote" }
 	StringsCompare = Func { "strings", "Compare" }
 	StringsContains = Func { "strings", "Contains" }
 	StringsContainsAny = Func { "strings", "ContainsAny" }
@@ -174,15 +288,84 @@ var (
 	StringsTrimRight = Func { "strings", "TrimRight" }
 	StringsTrimSpace = Func { "strings", "TrimSpace" }
 	StringsTrimSuffix = Func { "strings", "TrimSuffix" }
+	TOMLMarshal = Func { "toml", "Marshal" }
+	TOMLUnmarshal = Func { "toml", "Unmarshal" }
+	TOMLValid = Func { "toml", "Valid" }
+	TextCenter = Func { "text", "Center" }
+	TextDedent = Func { "text", "Dedent" }
+	TextIndent = Func { "text", "Indent" }
+	TextNIndent = Func { "text", "NIndent" }
+	TextPadLeft = Func { "text", "PadLeft" }
+	TextPadRight = Func { "text", "PadRight" }
+	TextTruncate = Func { "text", "Truncate" }
+	TextWidth = Func { "text", "Width" }
+	TextWrap = Func { "text", "Wrap" }
+	TimeAdd = Func { "time", "Add" }
+	TimeAddDate = Func { "time", "AddDate" }
+	TimeAfter = Func { "time", "After" }
+	TimeBefore = Func { "time", "Before" }
+	TimeCompare = Func { "time", "Compare" }
+	TimeEqual = Func { "time", "Equal" }
+	TimeFormat = Func { "time", "Format" }
+	TimeIn = Func { "time", "In" }
+	TimeLayout = Func { "time", "Layout" }
+	TimeNow = Func { "time", "Now" }
+	TimeParse = Func { "time", "Parse" }
+	TimeParseDuration = Func { "time", "ParseDuration" }
+	TimeSince = Func { "time", "Since" }
+	TimeSub = Func { "time", "Sub" }
+	TimeTruncate = Func { "time", "Truncate" }
+	TimeUnix = Func { "time", "Unix" }
 	TmplExec = Func { "tmpl", "Exec" }
+	URLAddQuery = Func { "url", "AddQuery" }
+	URLEncode = Func { "url", "Encode" }
 	URLJoinPath = Func { "url", "JoinPath" }
+	URLParse = Func { "url", "Parse" }
 	URLPathEscape = Func { "url", "PathEscape" }
 	URLPathUnescape = Func { "url", "PathUnescape" }
+	URLQuery = Func { "url", "Query" }
 	URLQueryEscape = Func { "url", "QueryEscape" }
 	URLQueryUnescape = Func { "url", "QueryUnescape" }
+	URLResolveReference = Func { "url", "ResolveReference" }
+	URLSetQuery = Func { "url", "SetQuery" }
+	UUIDParse = Func { "uuid", "Parse" }
+	UUIDV4 = Func { "uuid", "V4" }
+	UUIDV5 = Func { "uuid", "V5" }
+	UUIDV7 = Func { "uuid", "V7" }
+	UUIDValid = Func { "uuid", "Valid" }
+	UUIDVersion = Func { "uuid", "Version" }
+	XMLEscapeAttr = Func { "xml", "EscapeAttr" }
+	XMLEscapeText = Func { "xml", "EscapeText" }
+	XMLMarshal = Func { "xml", "Marshal" }
+	XMLMarshalIndent = Func { "xml", "MarshalIndent" }
+	XMLQuery = Func { "xml", "Query" }
+	XMLQueryText = Func { "xml", "QueryText" }
+	XMLUnmarshal = Func { "xml", "Unmarshal" }
+	YAMLJoin = Func { "yaml", "Join" }
+	YAMLMarshal = Func { "yaml", "Marshal" }
+	YAMLSplit = Func { "yaml", "Split" }
+	YAMLUnmarshal = Func { "yaml", "Unmarshal" }
+	YAMLValid = Func { "yaml", "Valid" }
 )
 // Collections
 var (
+	CSV = Funcs {
+		CSVEncode,
+		CSVParse,
+		CSVParseWithHeader,
+	}
+
+	Case = Funcs {
+		CaseCamel,
+		CaseKebab,
+		CasePascal,
+		CaseScreamingSnake,
+		CaseSentence,
+		CaseSnake,
+		CaseSplit,
+		CaseTitle,
+	}
+
 	Cmp = Funcs {
 		CmpOr,
 	}
@@ -218,6 +401,19 @@ var (
 		ConvToUints,
 	}
 
+	Decimal = Funcs {
+		DecimalAdd,
+		DecimalCmp,
+		DecimalDiv,
+		DecimalEqual,
+		DecimalFormat,
+		DecimalMul,
+		DecimalNew,
+		DecimalRound,
+		DecimalSub,
+		DecimalSum,
+	}
+
 	Dict = Funcs {
 		DictHasKey,
 		DictHasValue,
@@ -226,6 +422,23 @@ var (
 		DictNew,
 	}
 
+	Encoding = Funcs {
+		EncodingASCII85Decode,
+		EncodingASCII85Encode,
+		EncodingBase32Decode,
+		EncodingBase32Encode,
+		EncodingBase64Decode,
+		EncodingBase64Encode,
+		EncodingBase64RawDecode,
+		EncodingBase64RawEncode,
+		EncodingBase64RawURLDecode,
+		EncodingBase64RawURLEncode,
+		EncodingBase64URLDecode,
+		EncodingBase64URLEncode,
+		EncodingHexDecode,
+		EncodingHexEncode,
+	}
+
 	FilePath = Funcs {
 		FilePathAbs,
 		FilePathBase,
@@ -233,9 +446,41 @@ var (
 		FilePathDir,
 		FilePathExt,
 		FilePathFromSlash,
+		FilePathGlob,
+		FilePathIsAbs,
+		FilePathIsLocal,
 		FilePathJoin,
+		FilePathLocalize,
+		FilePathMatch,
 		FilePathRel,
+		FilePathSplit,
+		FilePathSplitList,
 		FilePathToSlash,
+		FilePathVolumeName,
+	}
+
+	Fmt = Funcs {
+		FmtSprint,
+		FmtSprintf,
+		FmtSprintln,
+	}
+
+	HTML = Funcs {
+		HTMLAttr,
+		HTMLEscapeString,
+		HTMLStripTags,
+		HTMLUnescapeString,
+	}
+
+	Hash = Funcs {
+		HashCRC32,
+		HashFNV32a,
+		HashFNV64a,
+		HashHMACSHA256,
+		HashMD5,
+		HashSHA1,
+		HashSHA256,
+		HashSHA512,
 	}
 
 	JSON = Funcs {
@@ -248,6 +493,38 @@ var (
 		JSONValid,
 	}
 
+	Math = Funcs {
+		MathAbs,
+		MathAdd,
+		MathCeil,
+		MathClamp,
+		MathDiv,
+		MathFloor,
+		MathMax,
+		MathMin,
+		MathMod,
+		MathMul,
+		MathPow,
+		MathRound,
+		MathSqrt,
+		MathSub,
+		MathSum,
+	}
+
+	Net = Funcs {
+		NetContains,
+		NetHost,
+		NetIsIPv4,
+		NetIsIPv6,
+		NetJoinHostPort,
+		NetNext,
+		NetParseAddr,
+		NetParsePrefix,
+		NetPrev,
+		NetSplitHostPort,
+		NetSubnet,
+	}
+
 	OS = Funcs {
 		OSChdir,
 		OSChmod,
@@ -305,7 +582,21 @@ var (
 		PathClean,
 		PathDir,
 		PathExt,
+		PathIsAbs,
 		PathJoin,
+		PathMatch,
+		PathSplit,
+	}
+
+	Rand = Funcs {
+		RandChoice,
+		RandCryptoString,
+		RandFloat,
+		RandInt,
+		RandIntN,
+		RandSample,
+		RandShuffle,
+		RandString,
 	}
 
 	Regexp = Funcs {
@@ -324,6 +615,17 @@ var (
 		RegexpSplit,
 	}
 
+	Semver = Funcs {
+		SemverBumpMajor,
+		SemverBumpMinor,
+		SemverBumpPatch,
+		SemverCompare,
+		SemverParse,
+		SemverSatisfies,
+		SemverSort,
+		SemverValid,
+	}
+
 	Slice = Funcs {
 		SliceAppend,
 		SliceCompact,
@@ -342,6 +644,20 @@ var (
 		SliceUnique,
 	}
 
+	Strconv = Funcs {
+		StrconvAppendQuote,
+		StrconvAppendQuoteJSON,
+		StrconvAppendQuoteToASCII,
+		StrconvCanBackquote,
+		StrconvFormatFloat,
+		StrconvFormatInt,
+		StrconvParseInt,
+		StrconvQuote,
+		StrconvQuoteJSON,
+		StrconvQuoteToASCII,
+		StrconvUnquote,
+	}
+
 	Strings = Funcs {
 		StringsCompare,
 		StringsContains,
@@ -383,19 +699,100 @@ var (
 		StringsTrimSuffix,
 	}
 
+	TOML = Funcs {
+		TOMLMarshal,
+		TOMLUnmarshal,
+		TOMLValid,
+	}
+
+	Text = Funcs {
+		TextCenter,
+		TextDedent,
+		TextIndent,
+		TextNIndent,
+		TextPadLeft,
+		TextPadRight,
+		TextTruncate,
+		TextWidth,
+		TextWrap,
+	}
+
+	Time = Funcs {
+		TimeAdd,
+		TimeAddDate,
+		TimeAfter,
+		TimeBefore,
+		TimeCompare,
+		TimeEqual,
+		TimeFormat,
+		TimeIn,
+		TimeLayout,
+		TimeNow,
+		TimeParse,
+		TimeParseDuration,
+		TimeSince,
+		TimeSub,
+		TimeTruncate,
+		TimeUnix,
+	}
+
 	Tmpl = Funcs {
 		TmplExec,
 	}
 
 	URL = Funcs {
+		URLAddQuery,
+		URLEncode,
 		URLJoinPath,
+		URLParse,
 		URLPathEscape,
 		URLPathUnescape,
+		URLQuery,
 		URLQueryEscape,
 		URLQueryUnescape,
+		URLResolveReference,
+		URLSetQuery,
+	}
+
+	UUID = Funcs {
+		UUIDParse,
+		UUIDV4,
+		UUIDV5,
+		UUIDV7,
+		UUIDValid,
+		UUIDVersion,
+	}
+
+	XML = Funcs {
+		XMLEscapeAttr,
+		XMLEscapeText,
+		XMLMarshal,
+		XMLMarshalIndent,
+		XMLQuery,
+		XMLQueryText,
+		XMLUnmarshal,
+	}
+
+	YAML = Funcs {
+		YAMLJoin,
+		YAMLMarshal,
+		YAMLSplit,
+		YAMLUnmarshal,
+		YAMLValid,
 	}
 
 	All = Funcs {
+		CSVEncode,
+		CSVParse,
+		CSVParseWithHeader,
+		CaseCamel,
+		CaseKebab,
+		CasePascal,
+		CaseScreamingSnake,
+		CaseSentence,
+		CaseSnake,
+		CaseSplit,
+		CaseTitle,
 		CmpOr,
 		ConvToBool,
 		ConvToBools,
@@ -425,20 +822,67 @@ var (
 		ConvToUint8,
 		ConvToUint8s,
 		ConvToUints,
+		DecimalAdd,
+		DecimalCmp,
+		DecimalDiv,
+		DecimalEqual,
+		DecimalFormat,
+		DecimalMul,
+		DecimalNew,
+		DecimalRound,
+		DecimalSub,
+		DecimalSum,
 		DictHasKey,
 		DictHasValue,
 		DictIsEmpty,
 		DictKeys,
 		DictNew,
+		EncodingASCII85Decode,
+		EncodingASCII85Encode,
+		EncodingBase32Decode,
+		EncodingBase32Encode,
+		EncodingBase64Decode,
+		EncodingBase64Encode,
+		EncodingBase64RawDecode,
+		EncodingBase64RawEncode,
+		EncodingBase64RawURLDecode,
+		EncodingBase64RawURLEncode,
+		EncodingBase64URLDecode,
+		EncodingBase64URLEncode,
+		EncodingHexDecode,
+		EncodingHexEncode,
 		FilePathAbs,
 		FilePathBase,
 		FilePathClean,
 		FilePathDir,
 		FilePathExt,
 		FilePathFromSlash,
+		FilePathGlob,
+		FilePathIsAbs,
+		FilePathIsLocal,
 		FilePathJoin,
+		FilePathLocalize,
+		FilePathMatch,
 		FilePathRel,
+		FilePathSplit,
+		FilePathSplitList,
 		FilePathToSlash,
+		FilePathVolumeName,
+		FmtSprint,
+		FmtSprintf,
+		FmtSprintln,
+		HTMLAttr,
+		HTMLEscapeString,
+		HTMLStripTags,
+		HTMLUnescapeString,
+		HashCRC32,
+		HashFNV32a,
+		HashFNV64a,
+		HashHMACSHA256,
+		HashMD5,
+		HashSHA1,
+		HashSHA256,
+		HashSHA512,
 		JSONCompact,
 		JSONHTMLEscape,
 		JSONIndent,
@@ -446,6 +890,32 @@ var (
 		JSONMarshalIndent,
 		JSONUnmarshal,
 		JSONValid,
+		MathAbs,
+		MathAdd,
+		MathCeil,
+		MathClamp,
+		MathDiv,
+		MathFloor,
+		MathMax,
+		MathMin,
+		MathMod,
+		MathMul,
+		MathPow,
+		MathRound,
+		MathSqrt,
+		MathSub,
+		MathSum,
+		NetContains,
+		NetHost,
+		NetIsIPv4,
+		NetIsIPv6,
+		NetJoinHostPort,
+		NetNext,
+		NetParseAddr,
+		NetParsePrefix,
+		NetPrev,
+		NetSplitHostPort,
+		NetSubnet,
 		OSChdir,
 		OSChmod,
 		OSChown,
@@ -499,7 +969,18 @@ var (
 		PathClean,
 		PathDir,
 		PathExt,
+		PathIsAbs,
 		PathJoin,
+		PathMatch,
+		PathSplit,
+		RandChoice,
+		RandCryptoString,
+		RandFloat,
+		RandInt,
+		RandIntN,
+		RandSample,
+		RandShuffle,
+		RandString,
 		RegexpFindAllString,
 		RegexpFindAllStringIndex,
 		RegexpFindAllStringSubmatch,
@@ -513,6 +994,14 @@ var (
 		RegexpReplaceAllLiteralString,
 		RegexpReplaceAllString,
 		RegexpSplit,
+		SemverBumpMajor,
+		SemverBumpMinor,
+		SemverBumpPatch,
+		SemverCompare,
+		SemverParse,
+		SemverSatisfies,
+		SemverSort,
+		SemverValid,
 		SliceAppend,
 		SliceCompact,
 		SliceContains,
@@ -528,6 +1017,17 @@ var (
 		SliceReverse,
 		SliceSort,
 		SliceUnique,
+		StrconvAppendQuote,
+		StrconvAppendQuoteJSON,
+		StrconvAppendQuoteToASCII,
+		StrconvCanBackquote,
+		StrconvFormatFloat,
+		StrconvFormatInt,
+		StrconvParseInt,
+		StrconvQuote,
+		StrconvQuoteJSON,
+		StrconvQuoteToASCII,
+		StrconvUnquote,
 		StringsCompare,
 		StringsContains,
 		StringsContainsAny,
@@ -566,15 +1066,82 @@ var (
 		StringsTrimRight,
 		StringsTrimSpace,
 		StringsTrimSuffix,
+		TOMLMarshal,
+		TOMLUnmarshal,
+		TOMLValid,
+		TextCenter,
+		TextDedent,
+		TextIndent,
+		TextNIndent,
+		TextPadLeft,
+		TextPadRight,
+		TextTruncate,
+		TextWidth,
+		TextWrap,
+		TimeAdd,
+		TimeAddDate,
+		TimeAfter,
+		TimeBefore,
+		TimeCompare,
+		TimeEqual,
+		TimeFormat,
+		TimeIn,
+		TimeLayout,
+		TimeNow,
+		TimeParse,
+		TimeParseDuration,
+		TimeSince,
+		TimeSub,
+		TimeTruncate,
+		TimeUnix,
 		TmplExec,
+		URLAddQuery,
+		URLEncode,
 		URLJoinPath,
+		URLParse,
 		URLPathEscape,
 		URLPathUnescape,
+		URLQuery,
 		URLQueryEscape,
 		URLQueryUnescape,
+		URLResolveReference,
+		URLSetQuery,
+		UUIDParse,
+		UUIDV4,
+		UUIDV5,
+		UUIDV7,
+		UUIDValid,
+		UUIDVersion,
+		XMLEscapeAttr,
+		XMLEscapeText,
+		XMLMarshal,
+		XMLMarshalIndent,
+		XMLQuery,
+		XMLQueryText,
+		XMLUnmarshal,
+		YAMLJoin,
+		YAMLMarshal,
+		YAMLSplit,
+		YAMLUnmarshal,
+		YAMLValid,
 	}
 )
 var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
+	"csv": {
+		"Encode": {},
+		"Parse": {},
+		"ParseWithHeader": {},
+	},
+	"case": {
+		"Camel": {},
+		"Kebab": {},
+		"Pascal": {},
+		"ScreamingSnake": {},
+		"Sentence": {},
+		"Snake": {},
+		"Split": {},
+		"Title": {},
+	},
 	"cmp": {
 		"Or": {},
 	},
@@ -608,6 +1175,18 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"ToUint8s": {},
 		"ToUints": {},
 	},
+	"decimal": {
+		"Add": {},
+		"Cmp": {},
+		"Div": {},
+		"Equal": {},
+		"Format": {},
+		"Mul": {},
+		"New": {},
+		"Round": {},
+		"Sub": {},
+		"Sum": {},
+	},
 	"dict": {
 		"HasKey": {},
 		"HasValue": {},
@@ -615,6 +1194,22 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"Keys": {},
 		"New": {},
 	},
+	"encoding": {
+		"ASCII85Decode": {},
+		"ASCII85Encode": {},
+		"Base32Decode": {},
+		"Base32Encode": {},
+		"Base64Decode": {},
+		"Base64Encode": {},
+		"Base64RawDecode": {},
+		"Base64RawEncode": {},
+		"Base64RawURLDecode": {},
+		"Base64RawURLEncode": {},
+		"Base64URLDecode": {},
+		"Base64URLEncode": {},
+		"HexDecode": {},
+		"HexEncode": {},
+	},
 	"filepath": {
 		"Abs": {},
 		"Base": {},
@@ -622,9 +1217,38 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"Dir": {},
 		"Ext": {},
 		"FromSlash": {},
+		"Glob": {},
+		"IsAbs": {},
+		"IsLocal": {},
 		"Join": {},
+		"Localize": {},
+		"Match": {},
 		"Rel": {},
+		"Split": {},
+		"SplitList": {},
 		"ToSlash": {},
+		"VolumeName": {},
+	},
+	"fmt": {
+		"Sprint": {},
+		"Sprintf": {},
+		"Sprintln": {},
+	},
+	"html": {
+		"Attr": {},
+		"EscapeString": {},
+		"StripTags": {},
+		"UnescapeString": {},
+	},
+	"hash": {
+		"CRC32": {},
+		"FNV32a": {},
+		"FNV64a": {},
+		"HMACSHA256": {},
+		"MD5": {},
+		"SHA1": {},
+		"SHA256": {},
+		"SHA512": {},
 	},
 	"json": {
 		"Compact": {},
@@ -635,6 +1259,36 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"Unmarshal": {},
 		"Valid": {},
 	},
+	"math": {
+		"Abs": {},
+		"Add": {},
+		"Ceil": {},
+		"Clamp": {},
+		"Div": {},
+		"Floor": {},
+		"Max": {},
+		"Min": {},
+		"Mod": {},
+		"Mul": {},
+		"Pow": {},
+		"Round": {},
+		"Sqrt": {},
+		"Sub": {},
+		"Sum": {},
+	},
+	"net": {
+		"Contains": {},
+		"Host": {},
+		"IsIPv4": {},
+		"IsIPv6": {},
+		"JoinHostPort": {},
+		"Next": {},
+		"ParseAddr": {},
+		"ParsePrefix": {},
+		"Prev": {},
+		"SplitHostPort": {},
+		"Subnet": {},
+	},
 	"os": {
 		"Chdir": {},
 		"Chmod": {},
@@ -691,7 +1345,20 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"Clean": {},
 		"Dir": {},
 		"Ext": {},
+		"IsAbs": {},
 		"Join": {},
+		"Match": {},
+		"Split": {},
+	},
+	"rand": {
+		"Choice": {},
+		"CryptoString": {},
+		"Float": {},
+		"Int": {},
+		"IntN": {},
+		"Sample": {},
+		"Shuffle": {},
+		"String": {},
 	},
 	"regexp": {
 		"FindAllString": {},
@@ -708,6 +1375,16 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"ReplaceAllString": {},
 		"Split": {},
 	},
+	"semver": {
+		"BumpMajor": {},
+		"BumpMinor": {},
+		"BumpPatch": {},
+		"Compare": {},
+		"Parse": {},
+		"Satisfies": {},
+		"Sort": {},
+		"Valid": {},
+	},
 	"slice": {
 		"Append": {},
 		"Compact": {},
@@ -725,6 +1402,19 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"Sort": {},
 		"Unique": {},
 	},
+	"strconv": {
+		"AppendQuote": {},
+		"AppendQuoteJSON": {},
+		"AppendQuoteToASCII": {},
+		"CanBackquote": {},
+		"FormatFloat": {},
+		"FormatInt": {},
+		"ParseInt": {},
+		"Quote": {},
+		"QuoteJSON": {},
+		"QuoteToASCII": {},
+		"Unquote": {},
+	},
 	"strings": {
 		"Compare": {},
 		"Contains": {},
@@ -765,14 +1455,78 @@ var NamespacesAndTheirFunctions = map[string]map[string]struct{}{
 		"TrimSpace": {},
 		"TrimSuffix": {},
 	},
+	"toml": {
+		"Marshal": {},
+		"Unmarshal": {},
+		"Valid": {},
+	},
+	"text": {
+		"Center": {},
+		"Dedent": {},
+		"Indent": {},
+		"NIndent": {},
+		"PadLeft": {},
+		"PadRight": {},
+		"Truncate": {},
+		"Width": {},
+		"Wrap": {},
+	},
+	"time": {
+		"Add": {},
+		"AddDate": {},
+		"After": {},
+		"Before": {},
+		"Compare": {},
+		"Equal": {},
+		"Format": {},
+		"In": {},
+		"Layout": {},
+		"Now": {},
+		"Parse": {},
+		"ParseDuration": {},
+		"Since": {},
+		"Sub": {},
+		"Truncate": {},
+		"Unix": {},
+	},
 	"tmpl": {
 		"Exec": {},
 	},
 	"url": {
+		"AddQuery": {},
+		"Encode": {},
 		"JoinPath": {},
+		"Parse": {},
 		"PathEscape": {},
 		"PathUnescape": {},
+		"Query": {},
 		"QueryEscape": {},
 		"QueryUnescape": {},
+		"ResolveReference": {},
+		"SetQuery": {},
+	},
+	"uuid": {
+		"Parse": {},
+		"V4": {},
+		"V5": {},
+		"V7": {},
+		"Valid": {},
+		"Version": {},
+	},
+	"xml": {
+		"EscapeAttr": {},
+		"EscapeText": {},
+		"Marshal": {},
+		"MarshalIndent": {},
+		"Query": {},
+		"QueryText": {},
+		"Unmarshal": {},
+	},
+	"yaml": {
+		"Join": {},
+		"Marshal": {},
+		"Split": {},
+		"Unmarshal": {},
+		"Valid": {},
 	},
 }
@@ -0,0 +1,100 @@
+package funcs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// UnknownFuncError is returned when an identifier does not name a known namespace or function.
+type UnknownFuncError struct {
+	Identifier string
+}
+
+func (e *UnknownFuncError) Error() string {
+	return fmt.Sprintf("unknown function %q", e.Identifier)
+}
+
+// Lookup resolves a single identifier into the functions it refers to.
+// Supported identifiers are "namespace.Function" (e.g. "os.Getenv"), "namespace.*" (e.g. "strings.*")
+// and the collection names "safe" and "all".
+func Lookup(identifier string) (Funcs, error) {
+	identifier = strings.TrimSpace(identifier)
+	switch identifier {
+	case "safe":
+		return Safe, nil
+	case "all", "*":
+		return All, nil
+	}
+
+	namespace, name, ok := strings.Cut(identifier, ".")
+	if !ok {
+		return nil, &UnknownFuncError{Identifier: identifier}
+	}
+	nsSet, ok := NamespacesAndTheirFunctions[namespace]
+	if !ok {
+		return nil, &UnknownFuncError{Identifier: identifier}
+	}
+	if name == "*" {
+		names := make([]string, 0, len(nsSet))
+		for n := range nsSet {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		result := make(Funcs, 0, len(names))
+		for _, n := range names {
+			result = append(result, Func{Namespace: namespace, Name: n})
+		}
+		return result, nil
+	}
+	if _, ok := nsSet[name]; !ok {
+		return nil, &UnknownFuncError{Identifier: identifier}
+	}
+	return Funcs{{Namespace: namespace, Name: name}}, nil
+}
+
+// Parse resolves a comma separated list of identifiers, e.g. "strings.*,os.Getenv".
+// See Lookup for the supported identifiers. Empty entries are ignored.
+func Parse(list string) (Funcs, error) {
+	var result Funcs
+	for _, identifier := range strings.Split(list, ",") {
+		if strings.TrimSpace(identifier) == "" {
+			continue
+		}
+		f, err := Lookup(identifier)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f...)
+	}
+	return result, nil
+}
+
+// ParsePolicy reads a policy from r. A policy contains one or more identifiers per line,
+// separated by commas. Everything after a '#' is treated as a comment.
+//
+// Example:
+//
+//	# allow all string functions
+//	strings.*
+//	os.Getenv, os.Hostname
+func ParsePolicy(r io.Reader) (Funcs, error) {
+	var result Funcs
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		f, err := Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		result = append(result, f...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package funcs_test
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func sortedFuncs(f funcs.Funcs) funcs.Funcs {
+	return slices.SortedFunc(slices.Values(f), func(a, b funcs.Func) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		identifier string
+		want       funcs.Funcs
+	}{
+		{name: "function", identifier: "os.Getenv", want: funcs.Funcs{funcs.OSGetenv}},
+		{name: "spaces", identifier: "  strings.ToUpper\t", want: funcs.Funcs{funcs.StringsToUpper}},
+		{name: "safe", identifier: "safe", want: funcs.Safe},
+		{name: "all", identifier: "all", want: funcs.All},
+		{name: "star", identifier: "*", want: funcs.All},
+		{name: "namespace", identifier: "strings.*", want: sortedFuncs(funcs.Strings)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := funcs.Lookup(tt.identifier)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup_Unknown(t *testing.T) {
+	t.Parallel()
+
+	for _, identifier := range []string{"", "os", "Safe", "unknown.Func", "unknown.*", "os.Unknown", "os.getenv"} {
+		_, err := funcs.Lookup(identifier)
+		var unknownErr *funcs.UnknownFuncError
+		if !errors.As(err, &unknownErr) {
+			t.Errorf("Lookup(%q) error = %v, want UnknownFuncError", identifier, err)
+			continue
+		}
+		if unknownErr.Identifier != identifier {
+			t.Errorf("Lookup(%q) Identifier = %q, want %q", identifier, unknownErr.Identifier, identifier)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	got, err := funcs.Parse(" strings.ToUpper, ,os.Getenv,")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := (funcs.Funcs{funcs.StringsToUpper, funcs.OSGetenv}); !slices.Equal(got, want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+
+	got, err = funcs.Parse("")
+	if err != nil || len(got) != 0 {
+		t.Errorf("Parse(\"\") = %v, %v, want no functions", got, err)
+	}
+
+	_, err = funcs.Parse("os.Getenv, os.Unknown")
+	var unknownErr *funcs.UnknownFuncError
+	if !errors.As(err, &unknownErr) || unknownErr.Identifier != "os.Unknown" {
+		t.Errorf("Parse() error = %v, want UnknownFuncError for os.Unknown", err)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := `
+# allow upper casing
+strings.ToUpper # inline comment
+os.Getenv, os.Hostname
+
+`
+	got, err := funcs.ParsePolicy(strings.NewReader(policy))
+	if err != nil {
+		t.Fatalf("ParsePolicy() error = %v", err)
+	}
+	if want := (funcs.Funcs{funcs.StringsToUpper, funcs.OSGetenv, funcs.OSHostname}); !slices.Equal(got, want) {
+		t.Errorf("ParsePolicy() = %v, want %v", got, want)
+	}
+
+	_, err = funcs.ParsePolicy(strings.NewReader("strings.ToUpper\n\nos.Unknown # typo\n"))
+	var unknownErr *funcs.UnknownFuncError
+	if !errors.As(err, &unknownErr) || !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("ParsePolicy() error = %v, want UnknownFuncError on line 3", err)
+	}
+
+	readErr := errors.New("read failed")
+	_, err = funcs.ParsePolicy(iotest.ErrReader(readErr))
+	if !errors.Is(err, readErr) {
+		t.Errorf("ParsePolicy() error = %v, want %v", err, readErr)
+	}
+}
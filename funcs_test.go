@@ -129,3 +129,50 @@ func TestFuncNotAllowedError_Error(t *testing.T) {
 		return
 	}
 }
+
+func TestTmplExec_NotAllowed(t *testing.T) {
+	t.Parallel()
+
+	var ctx xtemplate.Tmpl
+	_, err := ctx.Exec("T1")
+	var funcNotAllowedError *xtemplate.FuncNotAllowedError
+	if !errors.As(err, &funcNotAllowedError) || funcNotAllowedError.Func != funcs.TmplExec {
+		t.Errorf("Exec() error = %v, want FuncNotAllowedError for %v", err, funcs.TmplExec)
+	}
+}
+
+func TestFuncNotAllowedError_BeforeArguments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		allowed []xtemplate.AllowedFunctions
+		want    funcs.Func
+	}{
+		{
+			name:    "json.Marshal with an invalid map key",
+			tmpl:    `{{ json.Marshal ( dict.New 1 2 ) }}`,
+			allowed: []xtemplate.AllowedFunctions{funcs.JSONValid, funcs.Dict},
+			want:    funcs.JSONMarshal,
+		},
+		{
+			name:    "regexp.FindAllStringIndex with FindAllString allowed",
+			tmpl:    `{{ regexp.FindAllStringIndex "a" "aa" -1 }}`,
+			allowed: []xtemplate.AllowedFunctions{funcs.RegexpFindAllString},
+			want:    funcs.RegexpFindAllStringIndex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xtemplate.QuickExecute(tt.tmpl, nil, tt.allowed...)
+			var funcNotAllowedError *xtemplate.FuncNotAllowedError
+			if !errors.As(err, &funcNotAllowedError) || funcNotAllowedError.Func != tt.want {
+				t.Errorf("QuickExecute() error = %v, want FuncNotAllowedError for %v", err, tt.want)
+			}
+		})
+	}
+}
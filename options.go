@@ -0,0 +1,283 @@
+package xtemplate
+
+import (
+	"cmp"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// Option configures the behavior of the functions returned by FuncMap.
+// Options are passed along with the allowed functions to FuncMap, QuickExecute and New.
+type Option func(*options)
+
+// Functions implements AllowedFunctions. An Option does not allow any functions.
+func (o Option) Functions() []funcs.Func {
+	return nil
+}
+
+type options struct {
+	deterministic  bool
+	identity       Identity
+	clock          func() time.Time
+	locationLoader func(name string) (*time.Location, error)
+	entropy        io.Reader
+	randSeed       *[2]uint64
+	randMu         sync.Mutex
+	rand           *rand.Rand
+	overrides      map[funcs.Func]any
+	fmtPolicy      FmtPolicy
+	fsys           fs.FS
+}
+
+func newOptions(allowedFunctions []AllowedFunctions) *options {
+	opts := &options{
+		deterministic:  false,
+		identity:       Identity{},
+		clock:          nil,
+		locationLoader: nil,
+		entropy:        nil,
+		randSeed:       nil,
+		randMu:         sync.Mutex{},
+		rand:           nil,
+		overrides:      nil,
+		fmtPolicy:      FmtPolicy{Verbs: "", MaxWidth: defaultFmtLimit, MaxPrecision: defaultFmtLimit},
+		fsys:           nil,
+	}
+	for _, f := range allowedFunctions {
+		if o, ok := f.(Option); ok && o != nil {
+			o(opts)
+		}
+	}
+	return opts
+}
+
+// Identity holds the values that nondeterministic functions return in deterministic mode.
+// Functions whose value is not set (zero) fail with a NondeterministicError.
+type Identity struct {
+	// Pid is returned by os.Getpid.
+	Pid int
+	// Ppid is returned by os.Getppid.
+	Ppid int
+	// Hostname is returned by os.Hostname.
+	Hostname string
+	// TempDir is returned by os.TempDir.
+	TempDir string
+	// Wd is returned by os.Getwd.
+	Wd string
+	// Executable is returned by os.Executable.
+	Executable string
+	// MkdirTemp is called by os.MkdirTemp.
+	MkdirTemp func(dir, pattern string) (string, error)
+}
+
+// NondeterministicError is returned when a nondeterministic function is called in deterministic mode
+// and the host did not supply a value for it.
+type NondeterministicError struct {
+	Func funcs.Func
+}
+
+func (e *NondeterministicError) Error() string {
+	return fmt.Sprintf("function %s.%s is not available in deterministic mode", e.Func.Namespace, e.Func.Name)
+}
+
+// WithDeterministic enables the deterministic mode, templates then produce identical output on every machine.
+// In deterministic mode
+//   - nondeterministic functions (os.Getpid, os.Getppid, os.Hostname, os.TempDir, os.MkdirTemp, os.Getwd and
+//     os.Executable) return the values of the given Identity, or fail with a NondeterministicError if the
+//     value was not supplied,
+//   - dict.Keys returns the keys in a stable order,
+//   - functions that depend on the current time fail unless a clock was supplied with WithClock,
+//   - time zones other than UTC fail to load unless a loader was supplied with WithLocationLoader,
+//   - functions that need random bytes (e.g. uuid.V4) fail unless a source was supplied with WithEntropy,
+//   - the rand namespace fails unless a seed was supplied with WithRandSeed.
+func WithDeterministic(identity Identity) Option {
+	return func(o *options) {
+		o.deterministic = true
+		o.identity = identity
+	}
+}
+
+// WithClock sets the clock that is used by functions that depend on the current time.
+func WithClock(clock func() time.Time) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithLocationLoader sets the function that loads time zones by name, e.g. time.LoadLocation or a loader
+// backed by an embedded time zone database.
+func WithLocationLoader(loader func(name string) (*time.Location, error)) Option {
+	return func(o *options) {
+		o.locationLoader = loader
+	}
+}
+
+// WithEntropy sets the source of random bytes, e.g. for uuid.V4 and uuid.V7.
+// By default crypto/rand is used. A fixed source makes the generated values reproducible in tests.
+func WithEntropy(r io.Reader) Option {
+	return func(o *options) {
+		o.entropy = r
+	}
+}
+
+// WithRandSeed seeds the pseudo-random source of the rand namespace. By default the source is randomly seeded.
+//
+// Every FuncMap starts a new source with this seed. Engine.Execute, Engine.ExecuteTemplate and QuickExecute
+// create a new FuncMap for every execution, so every execution produces the same sequence of values.
+// A template that is bound to a FuncMap once and executed several times continues the sequence instead,
+// bind a new FuncMap (or use an Engine) to restart it.
+func WithRandSeed(seed1, seed2 uint64) Option {
+	return func(o *options) {
+		o.randSeed = &[2]uint64{seed1, seed2}
+	}
+}
+
+// WithFS sets the file system that filepath.Glob searches, e.g. an os.DirFS rooted at a sandbox directory or
+// an embed.FS. Without a file system filepath.Glob fails with ErrNoFS.
+func WithFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.fsys = fsys
+	}
+}
+
+// FmtPolicy restricts the format strings accepted by the fmt namespace.
+type FmtPolicy struct {
+	// Verbs lists the allowed verbs, e.g. "vsdq". An empty string allows all verbs.
+	Verbs string
+	// MaxWidth is the largest allowed width, 0 forbids widths.
+	MaxWidth int
+	// MaxPrecision is the largest allowed precision, 0 forbids precisions.
+	MaxPrecision int
+}
+
+// defaultFmtLimit is the width and precision limit of the fmt namespace if no policy was set.
+const defaultFmtLimit = 1000
+
+// WithFmtPolicy sets the policy for format strings of the fmt namespace.
+// By default all verbs are allowed and widths and precisions are limited to 1000.
+func WithFmtPolicy(policy FmtPolicy) Option {
+	return func(o *options) {
+		o.fmtPolicy = policy
+	}
+}
+
+// now returns the current time of the configured clock.
+func (o *options) now(f funcs.Func) (time.Time, error) {
+	if o.clock != nil {
+		return o.clock(), nil
+	}
+	if o.deterministic {
+		return time.Time{}, &NondeterministicError{Func: f}
+	}
+	return time.Now(), nil
+}
+
+// loadLocation loads the time zone with the given name using the configured loader.
+func (o *options) loadLocation(f funcs.Func, name string) (*time.Location, error) {
+	if o.locationLoader != nil {
+		return o.locationLoader(name)
+	}
+	if name == "UTC" {
+		return time.UTC, nil
+	}
+	if o.deterministic {
+		return nil, &NondeterministicError{Func: f}
+	}
+	return time.LoadLocation(name)
+}
+
+// readRandom fills b with random bytes from the configured source.
+func (o *options) readRandom(f funcs.Func, b []byte) error {
+	if o.entropy != nil {
+		_, err := io.ReadFull(o.entropy, b)
+		return err
+	}
+	if o.deterministic {
+		return &NondeterministicError{Func: f}
+	}
+	_, err := cryptorand.Read(b)
+	return err
+}
+
+// withRand calls fn with the pseudo-random source, the source is created on first use and lives as long as
+// the FuncMap.
+func (o *options) withRand(f funcs.Func, fn func(r *rand.Rand)) error {
+	o.randMu.Lock()
+	defer o.randMu.Unlock()
+	if o.rand == nil {
+		switch {
+		case o.randSeed != nil:
+			o.rand = rand.New(rand.NewPCG(o.randSeed[0], o.randSeed[1])) //nolint:gosec // seeded on purpose
+		case o.deterministic:
+			return &NondeterministicError{Func: f}
+		default:
+			o.rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint:gosec // not used for secrets
+		}
+	}
+	fn(o.rand)
+	return nil
+}
+
+// sortAny sorts values in a stable order: booleans first, then numbers, then strings,
+// then all other values ordered by their type and formatted value.
+// Values that compare equal (e.g. 1 and 1.0) are ordered by their type name and formatted value.
+func sortAny(values []any) {
+	slices.SortStableFunc(values, compareAny)
+}
+
+func compareAny(a, b any) int {
+	ra, rb := sortRank(a), sortRank(b)
+	if ra != rb {
+		return cmp.Compare(ra, rb)
+	}
+	var c int
+	switch ra {
+	case rankBool, rankNumber:
+		c = cmp.Compare(toFloat64OrZero(a), toFloat64OrZero(b))
+	case rankString:
+		c = cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+	}
+	if c != 0 {
+		return c
+	}
+	if c := cmp.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+const (
+	rankBool = iota
+	rankNumber
+	rankString
+	rankOther
+)
+
+func sortRank(v any) int {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool:
+		return rankBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return rankNumber
+	case reflect.String:
+		return rankString
+	default:
+		return rankOther
+	}
+}
+
+func toFloat64OrZero(v any) float64 {
+	f, _ := toFloat64(v)
+	return f
+}
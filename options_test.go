@@ -0,0 +1,137 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestWithDeterministic(t *testing.T) {
+	t.Parallel()
+
+	identity := xtemplate.Identity{
+		Hostname: "build-host",
+		Pid:      1,
+		MkdirTemp: func(dir, pattern string) (string, error) {
+			return dir + "/" + pattern + "1", nil
+		},
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "supplied values",
+			tmpl: `{{ os.Hostname }} {{ os.Getpid }} {{ os.MkdirTemp "/tmp" "build" }}`,
+			want: "build-host 1 /tmp/build1",
+		},
+		{
+			name:    "missing value",
+			tmpl:    `{{ os.Getwd }}`,
+			wantErr: &xtemplate.NondeterministicError{Func: funcs.OSGetwd},
+		},
+		{
+			name: "stable dict keys",
+			tmpl: `{{ dict.Keys ( dict.New "b" 1 "a" 2 3 3 true 4 1.5 5 "c" 6 ) }}`,
+			want: "[true 1.5 3 a b c]",
+		},
+		{
+			name: "stable dict keys with equal numbers",
+			tmpl: `{{ range dict.Keys ( dict.New 1 "a" 1.0 "b" 9007199254740993 "c" 9007199254740992 "d" ) }}` +
+				`{{ printf "%T %v" . . }};{{ end }}`,
+			want: "float64 1;int 1;int 9007199254740992;int 9007199254740993;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, funcs.All, xtemplate.WithDeterministic(identity))
+			if tt.wantErr != nil {
+				var nondeterministicErr *xtemplate.NondeterministicError
+				if !errors.As(err, &nondeterministicErr) {
+					t.Fatalf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+				}
+				if nondeterministicErr.Error() != tt.wantErr.Error() {
+					t.Errorf("QuickExecute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuickExecute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	clock := xtemplate.WithClock(func() time.Time {
+		return time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+	})
+	berlin := time.FixedZone("CET", 60*60)
+	loader := xtemplate.WithLocationLoader(func(name string) (*time.Location, error) {
+		if name == "Europe/Berlin" {
+			return berlin, nil
+		}
+		return nil, errors.New("unknown time zone " + name)
+	})
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		options []xtemplate.AllowedFunctions
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "injected clock",
+			tmpl:    `{{ time.Format time.Now "RFC3339" }} {{ time.Since "2024-02-29T12:00:00Z" }}`,
+			options: []xtemplate.AllowedFunctions{clock, xtemplate.WithDeterministic(xtemplate.Identity{})},
+			want:    "2024-02-29T12:30:00Z 30m0s",
+		},
+		{
+			name:    "deterministic without clock",
+			tmpl:    `{{ time.Now }}`,
+			options: []xtemplate.AllowedFunctions{xtemplate.WithDeterministic(xtemplate.Identity{})},
+			wantErr: true,
+		},
+		{
+			name:    "injected location loader",
+			tmpl:    `{{ time.Format ( time.In time.Now "Europe/Berlin" ) "15:04 MST" }}`,
+			options: []xtemplate.AllowedFunctions{clock, loader, xtemplate.WithDeterministic(xtemplate.Identity{})},
+			want:    "13:30 CET",
+		},
+		{
+			name:    "deterministic without location loader",
+			tmpl:    `{{ time.In "2024-02-29T12:30:00Z" "Europe/Berlin" }}`,
+			options: []xtemplate.AllowedFunctions{xtemplate.WithDeterministic(xtemplate.Identity{})},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := xtemplate.QuickExecute(tt.tmpl, nil, append(tt.options, funcs.Time)...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QuickExecute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("QuickExecute() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package xtemplate
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// namespaceTypes maps each namespace to the type that implements its functions.
+//
+//nolint:gochecknoglobals // lookup table
+var namespaceTypes = map[string]reflect.Type{
+	"case":     reflect.TypeFor[Case](),
+	"cmp":      reflect.TypeFor[Cmp](),
+	"conv":     reflect.TypeFor[Conv](),
+	"csv":      reflect.TypeFor[CSV](),
+	"decimal":  reflect.TypeFor[Decimal](),
+	"dict":     reflect.TypeFor[Dict](),
+	"encoding": reflect.TypeFor[Encoding](),
+	"filepath": reflect.TypeFor[FilePath](),
+	"fmt":      reflect.TypeFor[Fmt](),
+	"hash":     reflect.TypeFor[Hash](),
+	"html":     reflect.TypeFor[HTML](),
+	"json":     reflect.TypeFor[JSON](),
+	"math":     reflect.TypeFor[Math](),
+	"net":      reflect.TypeFor[Net](),
+	"os":       reflect.TypeFor[OS](),
+	"path":     reflect.TypeFor[Path](),
+	"rand":     reflect.TypeFor[Rand](),
+	"regexp":   reflect.TypeFor[Regexp](),
+	"semver":   reflect.TypeFor[Semver](),
+	"slice":    reflect.TypeFor[Slice](),
+	"strconv":  reflect.TypeFor[Strconv](),
+	"strings":  reflect.TypeFor[Strings](),
+	"text":     reflect.TypeFor[Text](),
+	"time":     reflect.TypeFor[Time](),
+	"tmpl":     reflect.TypeFor[Tmpl](),
+	"toml":     reflect.TypeFor[TOML](),
+	"url":      reflect.TypeFor[URL](),
+	"uuid":     reflect.TypeFor[UUID](),
+	"xml":      reflect.TypeFor[XML](),
+	"yaml":     reflect.TypeFor[YAML](),
+}
+
+// WithOverride replaces the implementation of f with fn.
+// fn must have the same signature as the function it replaces, e.g. func() (string, error) for os.Hostname.
+// The allowlist still applies, an overridden function can only be called if f is allowed.
+// WithOverride panics if f is unknown or if the signature of fn does not match.
+//
+// Example:
+//
+//	xtemplate.QuickExecute(`{{ os.Hostname }}`, nil,
+//		funcs.OSHostname,
+//		xtemplate.WithOverride(funcs.OSHostname, func() (string, error) {
+//			return "example.com", nil
+//		}),
+//	)
+func WithOverride(f funcs.Func, fn any) Option {
+	want, err := funcType(f)
+	if err != nil {
+		panic(err)
+	}
+	if got := reflect.TypeOf(fn); got != want {
+		panic(fmt.Sprintf("xtemplate: override for %s.%s must be of type %s, got %v", f.Namespace, f.Name, want, got))
+	}
+	return func(o *options) {
+		if o.overrides == nil {
+			o.overrides = make(map[funcs.Func]any)
+		}
+		o.overrides[f] = fn
+	}
+}
+
+// funcType returns the signature of the function f, without the receiver.
+func funcType(f funcs.Func) (reflect.Type, error) {
+	typ, ok := namespaceTypes[f.Namespace]
+	if !ok {
+		return nil, &funcs.UnknownFuncError{Identifier: f.Namespace + "." + f.Name}
+	}
+	method, ok := typ.MethodByName(f.Name)
+	if !ok {
+		return nil, &funcs.UnknownFuncError{Identifier: f.Namespace + "." + f.Name}
+	}
+	in := make([]reflect.Type, 0, method.Type.NumIn()-1)
+	for i := 1; i < method.Type.NumIn(); i++ {
+		in = append(in, method.Type.In(i))
+	}
+	out := make([]reflect.Type, 0, method.Type.NumOut())
+	for i := range method.Type.NumOut() {
+		out = append(out, method.Type.Out(i))
+	}
+	return reflect.FuncOf(in, out, method.Type.IsVariadic()), nil
+}
+
+// override returns the function that replaces f, if any.
+func override[F any](o *options, f funcs.Func) (F, bool) {
+	fn, ok := o.overrides[f].(F)
+	return fn, ok
+}
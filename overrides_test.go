@@ -0,0 +1,157 @@
+package xtemplate_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+func TestWithOverride(t *testing.T) {
+	t.Parallel()
+
+	hostname := xtemplate.WithOverride(funcs.OSHostname, func() (string, error) {
+		return "example.com", nil
+	})
+	getenv := xtemplate.WithOverride(funcs.OSGetenv, func(key string) (string, error) {
+		return "value of " + key, nil
+	})
+
+	got, err := xtemplate.QuickExecute(
+		`{{ os.Hostname }} {{ os.Getenv "HOME" }}`,
+		nil,
+		funcs.OSHostname, funcs.OSGetenv, hostname, getenv,
+	)
+	if err != nil {
+		t.Fatalf("QuickExecute() error = %v", err)
+	}
+	if want := "example.com value of HOME"; got != want {
+		t.Errorf("QuickExecute() got = %q, want %q", got, want)
+	}
+
+	_, err = xtemplate.QuickExecute(`{{ os.Hostname }}`, nil, funcs.OSGetenv, hostname)
+	var funcNotAllowedError *xtemplate.FuncNotAllowedError
+	if !errors.As(err, &funcNotAllowedError) {
+		t.Errorf("QuickExecute() error = %v, want FuncNotAllowedError", err)
+	}
+}
+
+func TestWithOverride_Panics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		f         funcs.Func
+		fn        any
+		wantPanic string
+	}{
+		{
+			name:      "wrong signature",
+			f:         funcs.OSHostname,
+			fn:        func() string { return "" },
+			wantPanic: "override for os.Hostname must be of type func() (string, error)",
+		},
+		{
+			name:      "unknown function",
+			f:         funcs.Func{Namespace: "os", Name: "Unknown"},
+			fn:        func() string { return "" },
+			wantPanic: `unknown function "os.Unknown"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				r := recover()
+				if r == nil || !strings.Contains(fmt.Sprint(r), tt.wantPanic) {
+					t.Errorf("WithOverride() panic = %v, want %q", r, tt.wantPanic)
+				}
+			}()
+			xtemplate.WithOverride(tt.f, tt.fn)
+		})
+	}
+}
+
+func TestWithOverride_AllFunctions(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range funcs.All {
+		func() {
+			defer func() {
+				r := recover()
+				if !strings.Contains(fmt.Sprint(r), "must be of type") {
+					t.Errorf("WithOverride(%s.%s) panic = %v, want signature mismatch", f.Namespace, f.Name, r)
+				}
+			}()
+			xtemplate.WithOverride(f, nil)
+		}()
+	}
+}
+
+func TestWithOverride_CalledByAllFunctions(t *testing.T) {
+	t.Parallel()
+
+	errOverridden := errors.New("overridden")
+	for _, f := range funcs.All {
+		t.Run(f.Namespace+"."+f.Name, func(t *testing.T) {
+			t.Parallel()
+
+			// the namespaces are only known as any, so the method and its signature are looked up with reflection
+			probe := xtemplate.FuncMap(template.New(""), f)
+			namespace, err := probe[f.Namespace].(func(...any) (any, error))()
+			if err != nil {
+				t.Fatalf("%s() error = %v", f.Namespace, err)
+			}
+			method := reflect.ValueOf(namespace).MethodByName(f.Name)
+			if !method.IsValid() {
+				t.Fatalf("%s has no method %s", f.Namespace, f.Name)
+			}
+
+			called := false
+			fn := reflect.MakeFunc(method.Type(), func([]reflect.Value) []reflect.Value {
+				called = true
+				out := make([]reflect.Value, method.Type().NumOut())
+				for i := range out {
+					out[i] = reflect.Zero(method.Type().Out(i))
+				}
+				if last := len(out) - 1; last >= 0 && method.Type().Out(last) == reflect.TypeFor[error]() {
+					out[last] = reflect.ValueOf(&errOverridden).Elem()
+				}
+				return out
+			})
+
+			m := xtemplate.FuncMap(template.New(""), f, xtemplate.WithOverride(f, fn.Interface()))
+			namespace, err = m[f.Namespace].(func(...any) (any, error))()
+			if err != nil {
+				t.Fatalf("%s() error = %v", f.Namespace, err)
+			}
+			method = reflect.ValueOf(namespace).MethodByName(f.Name)
+			in := make([]reflect.Value, method.Type().NumIn())
+			for i := range in {
+				in[i] = reflect.Zero(method.Type().In(i))
+			}
+			var out []reflect.Value
+			if method.Type().IsVariadic() {
+				out = method.CallSlice(in)
+			} else {
+				out = method.Call(in)
+			}
+
+			if !called {
+				t.Fatalf("%s.%s did not call its override", f.Namespace, f.Name)
+			}
+			if last := len(out) - 1; last >= 0 && method.Type().Out(last) == reflect.TypeFor[error]() {
+				if err, _ := out[last].Interface().(error); !errors.Is(err, errOverridden) {
+					t.Errorf("%s.%s error = %v, want %v", f.Namespace, f.Name, err, errOverridden)
+				}
+			}
+		})
+	}
+}
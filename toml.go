@@ -0,0 +1,951 @@
+package xtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// This file implements a TOML 1.0 decoder and encoder for the toml namespace.
+
+// maxTOMLDepth limits the nesting of arrays and inline tables.
+const maxTOMLDepth = 1000
+
+// tomlTable is a table while decoding, it tracks how the table was defined to reject redefinitions.
+type tomlTable struct {
+	values  map[string]any
+	defined bool // defined by a [table] header
+	dotted  bool // created by a dotted key
+	inline  bool // inline tables cannot be extended
+}
+
+// tomlTableArray is an array of tables while decoding.
+type tomlTableArray struct {
+	tables []*tomlTable
+}
+
+func newTOMLTable() *tomlTable {
+	return &tomlTable{values: make(map[string]any), defined: false, dotted: false, inline: false}
+}
+
+type tomlParser struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+	depth     int
+	root      *tomlTable
+	current   *tomlTable
+}
+
+// unmarshalTOML decodes a TOML document into a map[any]any.
+func unmarshalTOML(src string) (map[any]any, error) {
+	root := newTOMLTable()
+	p := &tomlParser{
+		src:       strings.ReplaceAll(src, "\r\n", "\n"),
+		pos:       0,
+		line:      1,
+		lineStart: 0,
+		depth:     0,
+		root:      root,
+		current:   root,
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	m, _ := tomlToGeneric(root).(map[any]any)
+	return m, nil
+}
+
+// tomlToGeneric converts the decoded tables to map[any]any and []any.
+func tomlToGeneric(v any) any {
+	switch tv := v.(type) {
+	case *tomlTable:
+		m := make(map[any]any, len(tv.values))
+		for k, v := range tv.values {
+			m[k] = tomlToGeneric(v)
+		}
+		return m
+	case *tomlTableArray:
+		out := make([]any, len(tv.tables))
+		for i, t := range tv.tables {
+			out[i] = tomlToGeneric(t)
+		}
+		return out
+	case []any:
+		out := make([]any, len(tv))
+		for i, v := range tv {
+			out[i] = tomlToGeneric(v)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return &SyntaxError{
+		Format: "toml",
+		Line:   p.line,
+		Column: p.pos - p.lineStart + 1,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	return p.peekAt(0)
+}
+
+func (p *tomlParser) peekAt(n int) byte {
+	if p.pos+n >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+n]
+}
+
+func (p *tomlParser) advance() {
+	if p.src[p.pos] == '\n' {
+		p.line++
+		p.lineStart = p.pos + 1
+	}
+	p.pos++
+}
+
+func (p *tomlParser) skipSpaces() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.advance()
+	}
+}
+
+// skipBlank skips whitespace, comments and line breaks.
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpaces()
+		if p.peek() == '#' {
+			for !p.eof() && p.peek() != '\n' {
+				p.advance()
+			}
+		}
+		if p.peek() != '\n' {
+			return
+		}
+		p.advance()
+	}
+}
+
+// expectLineEnd skips whitespace and a comment and expects the end of the line.
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpaces()
+	if p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.advance()
+		}
+	}
+	if !p.eof() && p.peek() != '\n' {
+		return p.errorf("expected the end of the line, got %q", p.peek())
+	}
+	return nil
+}
+
+func (p *tomlParser) parse() error {
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+		var err error
+		switch {
+		case p.peek() == '[' && p.peekAt(1) == '[':
+			err = p.parseTableArrayHeader()
+		case p.peek() == '[':
+			err = p.parseTableHeader()
+		default:
+			err = p.parseKeyValue(p.current)
+		}
+		if err != nil {
+			return err
+		}
+		if err := p.expectLineEnd(); err != nil {
+			return err
+		}
+	}
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+// parseKey parses a simple or dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpaces()
+		var key string
+		var err error
+		switch c := p.peek(); {
+		case c == '"':
+			key, err = p.parseBasicString()
+		case c == '\'':
+			key, err = p.parseLiteralString()
+		case isTOMLBareKeyChar(c):
+			start := p.pos
+			for isTOMLBareKeyChar(p.peek()) {
+				p.advance()
+			}
+			key = p.src[start:p.pos]
+		default:
+			return nil, p.errorf("expected a key")
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		p.skipSpaces()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.advance()
+	}
+}
+
+func (p *tomlParser) parseTableHeader() error {
+	p.advance() // '['
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.peek() != ']' {
+		return p.errorf("expected ']' after table name")
+	}
+	p.advance()
+	t := p.root
+	for i, key := range keys {
+		last := i == len(keys)-1
+		v, ok := t.values[key]
+		if !ok {
+			nt := newTOMLTable()
+			nt.defined = last
+			t.values[key] = nt
+			t = nt
+			continue
+		}
+		switch tv := v.(type) {
+		case *tomlTable:
+			if tv.inline || (last && (tv.defined || tv.dotted)) {
+				return p.errorf("table %s is already defined", strings.Join(keys[:i+1], "."))
+			}
+			if last {
+				tv.defined = true
+			}
+			t = tv
+		case *tomlTableArray:
+			if last {
+				return p.errorf("table %s is already defined as an array of tables", strings.Join(keys, "."))
+			}
+			t = tv.tables[len(tv.tables)-1]
+		default:
+			return p.errorf("key %s is already defined as a value", strings.Join(keys[:i+1], "."))
+		}
+	}
+	p.current = t
+	return nil
+}
+
+func (p *tomlParser) parseTableArrayHeader() error {
+	p.advance() // '['
+	p.advance() // '['
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.peek() != ']' || p.peekAt(1) != ']' {
+		return p.errorf("expected ']]' after array of tables name")
+	}
+	p.advance()
+	p.advance()
+	t := p.root
+	for i, key := range keys[:len(keys)-1] {
+		v, ok := t.values[key]
+		if !ok {
+			nt := newTOMLTable()
+			t.values[key] = nt
+			t = nt
+			continue
+		}
+		switch tv := v.(type) {
+		case *tomlTable:
+			if tv.inline {
+				return p.errorf("table %s is already defined", strings.Join(keys[:i+1], "."))
+			}
+			t = tv
+		case *tomlTableArray:
+			t = tv.tables[len(tv.tables)-1]
+		default:
+			return p.errorf("key %s is already defined as a value", strings.Join(keys[:i+1], "."))
+		}
+	}
+	nt := newTOMLTable()
+	nt.defined = true
+	last := keys[len(keys)-1]
+	switch tv := t.values[last].(type) {
+	case nil:
+		t.values[last] = &tomlTableArray{tables: []*tomlTable{nt}}
+	case *tomlTableArray:
+		tv.tables = append(tv.tables, nt)
+	default:
+		return p.errorf("key %s is not an array of tables", strings.Join(keys, "."))
+	}
+	p.current = nt
+	return nil
+}
+
+// parseKeyValue parses a key/value pair into t.
+func (p *tomlParser) parseKeyValue(t *tomlTable) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after key")
+	}
+	p.advance()
+	p.skipSpaces()
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+	for i, key := range keys[:len(keys)-1] {
+		v, ok := t.values[key]
+		if !ok {
+			nt := newTOMLTable()
+			nt.dotted = true
+			t.values[key] = nt
+			t = nt
+			continue
+		}
+		tv, ok := v.(*tomlTable)
+		if !ok || !tv.dotted || tv.inline {
+			return p.errorf("key %s is already defined", strings.Join(keys[:i+1], "."))
+		}
+		t = tv
+	}
+	last := keys[len(keys)-1]
+	if _, ok := t.values[last]; ok {
+		return p.errorf("key %s is already defined", strings.Join(keys, "."))
+	}
+	t.values[last] = value
+	return nil
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch c := p.peek(); {
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		return p.parseMultiLineBasicString()
+	case strings.HasPrefix(p.src[p.pos:], `'''`):
+		return p.parseMultiLineLiteralString()
+	case c == '"':
+		return p.parseBasicString()
+	case c == '\'':
+		return p.parseLiteralString()
+	case c == '[':
+		return p.parseArray()
+	case c == '{':
+		return p.parseInlineTable()
+	case c == 0 || c == '\n':
+		return nil, p.errorf("expected a value")
+	default:
+		return p.parseLiteralValue()
+	}
+}
+
+func (p *tomlParser) nest() error {
+	p.depth++
+	if p.depth > maxTOMLDepth {
+		return p.errorf("exceeded max depth of %d", maxTOMLDepth)
+	}
+	return nil
+}
+
+func (p *tomlParser) parseArray() ([]any, error) {
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	p.advance() // '['
+	out := make([]any, 0)
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.advance()
+			return out, nil
+		}
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.advance()
+		case ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (*tomlTable, error) {
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	p.advance() // '{'
+	t := newTOMLTable()
+	p.skipSpaces()
+	if p.peek() == '}' {
+		p.advance()
+		t.inline = true
+		return t, nil
+	}
+	for {
+		p.skipSpaces()
+		if err := p.parseKeyValue(t); err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.advance()
+		case '}':
+			p.advance()
+			markTOMLInline(t)
+			return t, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+func markTOMLInline(t *tomlTable) {
+	t.inline = true
+	for _, v := range t.values {
+		if tv, ok := v.(*tomlTable); ok {
+			markTOMLInline(tv)
+		}
+	}
+}
+
+//nolint:gochecknoglobals // lookup table
+var tomlEscapes = map[byte]string{
+	'b': "\b", 't': "\t", 'n': "\n", 'f': "\f", 'r': "\r", 'e': "\x1b", '"': "\"", '\\': "\\",
+}
+
+// parseEscape parses an escape sequence in a basic string, the position is after the backslash.
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	c := p.peek()
+	if s, ok := tomlEscapes[c]; ok {
+		b.WriteString(s)
+		p.advance()
+		return nil
+	}
+	size := map[byte]int{'u': 4, 'U': 8}[c]
+	if size == 0 || p.pos+1+size > len(p.src) {
+		return p.errorf("invalid escape sequence")
+	}
+	r, err := strconv.ParseUint(p.src[p.pos+1:p.pos+1+size], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(r)) {
+		return p.errorf("invalid escape sequence")
+	}
+	b.WriteRune(rune(r))
+	for range size + 1 {
+		p.advance()
+	}
+	return nil
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.advance() // '"'
+	var b strings.Builder
+	for {
+		switch c := p.peek(); {
+		case p.eof() || c == '\n':
+			return "", p.errorf("unterminated string")
+		case c == '"':
+			p.advance()
+			return b.String(), nil
+		case c == '\\':
+			p.advance()
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.advance()
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.advance() // '\''
+	start := p.pos
+	for p.peek() != '\'' {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		p.advance()
+	}
+	s := p.src[start:p.pos]
+	p.advance()
+	return s, nil
+}
+
+// closeMultiLine reports whether the delimiter at the current position closes a multi-line string
+// and consumes it. Up to two additional quotes before the delimiter belong to the string.
+func (p *tomlParser) closeMultiLine(b *strings.Builder, quote byte) bool {
+	n := 0
+	for p.peekAt(n) == quote {
+		n++
+	}
+	if n < 3 { //nolint:mnd // delimiter length
+		return false
+	}
+	if n > 5 { //nolint:mnd // delimiter and two quotes
+		n = 5
+	}
+	b.WriteString(strings.Repeat(string(quote), n-3))
+	for range n {
+		p.advance()
+	}
+	return true
+}
+
+func (p *tomlParser) parseMultiLineBasicString() (string, error) {
+	for range 3 {
+		p.advance()
+	}
+	if p.peek() == '\n' {
+		p.advance()
+	}
+	var b strings.Builder
+	for {
+		switch c := p.peek(); {
+		case p.eof():
+			return "", p.errorf("unterminated string")
+		case c == '"' && p.closeMultiLine(&b, '"'):
+			return b.String(), nil
+		case c == '\\':
+			p.advance()
+			// a line ending backslash trims all whitespace up to the next non-whitespace character
+			state := p.pos
+			p.skipSpaces()
+			if p.peek() == '\n' {
+				for p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\n' {
+					p.advance()
+				}
+				continue
+			}
+			if p.pos != state {
+				return "", p.errorf("invalid escape sequence")
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.advance()
+		}
+	}
+}
+
+func (p *tomlParser) parseMultiLineLiteralString() (string, error) {
+	for range 3 {
+		p.advance()
+	}
+	if p.peek() == '\n' {
+		p.advance()
+	}
+	var b strings.Builder
+	for {
+		switch c := p.peek(); {
+		case p.eof():
+			return "", p.errorf("unterminated string")
+		case c == '\'' && p.closeMultiLine(&b, '\''):
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+			p.advance()
+		}
+	}
+}
+
+//nolint:gochecknoglobals // lookup table
+var (
+	tomlIntRegexp      = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	tomlPrefixedRegexp = regexp.MustCompile(`^0(x[0-9A-Fa-f](_?[0-9A-Fa-f])*|o[0-7](_?[0-7])*|b[01](_?[01])*)$`)
+	tomlFloatRegexp    = regexp.MustCompile(
+		`^[+-]?(0|[1-9](_?[0-9])*)((\.[0-9](_?[0-9])*)([eE][+-]?[0-9](_?[0-9])*)?|[eE][+-]?[0-9](_?[0-9])*)$`,
+	)
+	tomlDateTimeRegexp = regexp.MustCompile(
+		`^(\d{4}-\d{2}-\d{2})([Tt ](\d{2}:\d{2}:\d{2})(\.\d+)?([Zz]|[+-]\d{2}:\d{2})?)?$`,
+	)
+	tomlTimeRegexp = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d+)?$`)
+)
+
+// parseLiteralValue parses booleans, numbers and dates.
+//
+//nolint:cyclop // cannot be simplified
+func (p *tomlParser) parseLiteralValue() (any, error) {
+	start := p.pos
+	startLine, startCol := p.line, p.pos-p.lineStart
+	for !p.eof() && strings.IndexByte(" \t\n#,]}", p.peek()) < 0 {
+		p.advance()
+	}
+	// a date and a time can be separated by a space
+	if token := p.src[start:p.pos]; len(token) == 10 && p.peek() == ' ' && //nolint:mnd // length of a date
+		p.peekAt(1) >= '0' && p.peekAt(1) <= '9' && tomlDateTimeRegexp.MatchString(token) {
+		p.advance()
+		for !p.eof() && strings.IndexByte(" \t\n#,]}", p.peek()) < 0 {
+			p.advance()
+		}
+	}
+	token := p.src[start:p.pos]
+	invalid := func() error {
+		return &SyntaxError{
+			Format: "toml",
+			Line:   startLine,
+			Column: startCol + 1,
+			Msg:    fmt.Sprintf("invalid value %q", token),
+		}
+	}
+
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+	switch {
+	case tomlIntRegexp.MatchString(token):
+		i, err := strconv.ParseInt(strings.ReplaceAll(token, "_", ""), 10, 64)
+		if err != nil {
+			return nil, invalid()
+		}
+		return int(i), nil
+	case tomlPrefixedRegexp.MatchString(token):
+		i, err := strconv.ParseInt(token, 0, 64)
+		if err != nil {
+			return nil, invalid()
+		}
+		return int(i), nil
+	case tomlFloatRegexp.MatchString(token):
+		f, err := strconv.ParseFloat(strings.ReplaceAll(token, "_", ""), 64)
+		if err != nil {
+			return nil, invalid()
+		}
+		return f, nil
+	case tomlTimeRegexp.MatchString(token):
+		t, err := time.Parse(tomlLocalTimeLayout, token)
+		if err != nil {
+			return nil, invalid()
+		}
+		return TOMLLocalTime{Time: t}, nil
+	}
+	m := tomlDateTimeRegexp.FindStringSubmatch(token)
+	if m == nil {
+		return nil, invalid()
+	}
+	layout := "2006-01-02"
+	value := m[1]
+	if m[2] != "" {
+		layout += "T15:04:05.999999999"
+		value += "T" + m[3] + m[4]
+		if m[5] != "" {
+			layout += "Z07:00"
+			value += strings.ToUpper(m[5])
+		}
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return nil, invalid()
+	}
+	switch {
+	case m[2] == "":
+		return TOMLLocalDate{Time: t}, nil
+	case m[5] == "":
+		return TOMLLocalDateTime{Time: t}, nil
+	default:
+		return t, nil
+	}
+}
+
+// marshalTOML encodes a map as a TOML document, keys are sorted.
+func marshalTOML(v any) (string, error) {
+	rv, err := normalizeTOMLValue(v)
+	if err != nil {
+		return "", err
+	}
+	if rv.Kind() != reflect.Map {
+		return "", fmt.Errorf("toml: top-level value must be a map, got %T", v) //nolint:err113 // allow dynamic error
+	}
+	var b strings.Builder
+	if err := encodeTOMLTable(&b, rv, nil); err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(b.String(), "\n"), nil
+}
+
+// normalizeTOMLValue is normalizeValue, but keeps time.Time and local date and time values which are native
+// TOML values.
+func normalizeTOMLValue(v any) (reflect.Value, error) {
+	switch t := v.(type) {
+	case time.Time, TOMLLocalDate, TOMLLocalTime, TOMLLocalDateTime:
+		return reflect.ValueOf(t), nil
+	case *time.Time:
+		if t != nil {
+			return reflect.ValueOf(*t), nil
+		}
+	}
+	return normalizeValue(v)
+}
+
+func sortedTOMLKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	anyKeys := make([]any, len(keys))
+	for i, k := range keys {
+		anyKeys[i] = k.Interface()
+	}
+	sortAny(anyKeys)
+	for i, k := range anyKeys {
+		keys[i] = reflect.ValueOf(k)
+	}
+	return keys
+}
+
+// tomlKind returns the normalized value, and whether it is a table or an array of tables.
+func tomlKind(v any) (reflect.Value, bool, bool, error) {
+	rv, err := normalizeTOMLValue(v)
+	if err != nil {
+		return rv, false, false, err
+	}
+	if rv.Kind() == reflect.Map {
+		return rv, true, false, nil
+	}
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() == 0 {
+		return rv, false, false, nil
+	}
+	for i := range rv.Len() {
+		el, err := normalizeTOMLValue(rv.Index(i).Interface())
+		if err != nil {
+			return rv, false, false, err
+		}
+		if el.Kind() != reflect.Map {
+			return rv, false, false, nil
+		}
+	}
+	return rv, false, true, nil
+}
+
+//nolint:cyclop // cannot be simplified
+func encodeTOMLTable(b *strings.Builder, rv reflect.Value, path []string) error {
+	keys := sortedTOMLKeys(rv)
+	var tables, arrays []reflect.Value
+	var values strings.Builder
+	for _, k := range keys {
+		v, isTable, isArray, err := tomlKind(rv.MapIndex(k).Interface())
+		if err != nil {
+			return err
+		}
+		switch {
+		case !v.IsValid():
+			// TOML has no null value
+		case isTable:
+			tables = append(tables, k)
+		case isArray:
+			arrays = append(arrays, k)
+		default:
+			s, err := formatTOMLValue(v)
+			if err != nil {
+				return err
+			}
+			values.WriteString(formatTOMLKey(toString(k.Interface())) + " = " + s + "\n")
+		}
+	}
+	if len(path) > 0 && (values.Len() > 0 || len(tables)+len(arrays) == 0) {
+		b.WriteString("\n[" + formatTOMLPath(path) + "]\n")
+	}
+	b.WriteString(values.String())
+	for _, k := range tables {
+		v, _ := normalizeTOMLValue(rv.MapIndex(k).Interface())
+		if err := encodeTOMLTable(b, v, append(slices.Clone(path), toString(k.Interface()))); err != nil {
+			return err
+		}
+	}
+	for _, k := range arrays {
+		arr, _ := normalizeTOMLValue(rv.MapIndex(k).Interface())
+		subPath := append(slices.Clone(path), toString(k.Interface()))
+		for i := range arr.Len() {
+			el, _ := normalizeTOMLValue(arr.Index(i).Interface())
+			var sub strings.Builder
+			if err := encodeTOMLTable(&sub, el, subPath); err != nil {
+				return err
+			}
+			// the header of an array of tables is always written
+			s := strings.TrimPrefix(sub.String(), "\n["+formatTOMLPath(subPath)+"]\n")
+			b.WriteString("\n[[" + formatTOMLPath(subPath) + "]]\n" + s)
+		}
+	}
+	return nil
+}
+
+func formatTOMLPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, k := range path {
+		keys[i] = formatTOMLKey(k)
+	}
+	return strings.Join(keys, ".")
+}
+
+func formatTOMLKey(k string) string {
+	if k == "" {
+		return `""`
+	}
+	for i := range len(k) {
+		if !isTOMLBareKeyChar(k[i]) {
+			return quoteTOMLString(k)
+		}
+	}
+	return k
+}
+
+func quoteTOMLString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// formatTOMLValue formats a value for a key/value pair or an inline array.
+//
+//nolint:cyclop, funlen // cannot be simplified
+func formatTOMLValue(rv reflect.Value) (string, error) {
+	if !rv.IsValid() {
+		return "", fmt.Errorf("toml: cannot encode null") //nolint:err113 // allow dynamic error
+	}
+	switch v := rv.Interface().(type) {
+	case json.Number:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case TOMLLocalDate, TOMLLocalTime, TOMLLocalDateTime:
+		return v.(fmt.Stringer).String(), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return "", fmt.Errorf("toml: %d overflows a TOML integer", rv.Uint()) //nolint:err113 // allow dynamic error
+		}
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsInf(f, 1):
+			return "inf", nil
+		case math.IsInf(f, -1):
+			return "-inf", nil
+		case math.IsNaN(f):
+			return "nan", nil
+		}
+		bitSize := 64
+		if rv.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		s := strconv.FormatFloat(f, 'g', -1, bitSize)
+		if !strings.ContainsAny(s, ".e") {
+			// integral floats keep a fraction, e.g. 1.0, to stay floats
+			s += ".0"
+		}
+		return s, nil
+	case reflect.String:
+		return quoteTOMLString(rv.String()), nil
+	case reflect.Map:
+		keys := sortedTOMLKeys(rv)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			v, err := normalizeTOMLValue(rv.MapIndex(k).Interface())
+			if err != nil {
+				return "", err
+			}
+			if !v.IsValid() {
+				continue
+			}
+			s, err := formatTOMLValue(v)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, formatTOMLKey(toString(k.Interface()))+" = "+s)
+		}
+		if len(parts) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	case reflect.Slice, reflect.Array:
+		if s, ok := yamlString(rv); ok {
+			return quoteTOMLString(s), nil
+		}
+		parts := make([]string, rv.Len())
+		for i := range rv.Len() {
+			v, err := normalizeTOMLValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			if parts[i], err = formatTOMLValue(v); err != nil {
+				return "", err
+			}
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("toml: unsupported type %s", rv.Type()) //nolint:err113 // allow dynamic error
+	}
+}
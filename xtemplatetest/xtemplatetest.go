@@ -0,0 +1,245 @@
+// Package xtemplatetest provides a golden file test harness for templates executed with xtemplate.
+//
+// Run walks a directory of test cases, every sub directory that contains a template file is a test case:
+//
+//	testdata/
+//	  greeting/
+//	    template.tmpl  the template to render (required)
+//	    data.json      the data passed to the template (optional)
+//	    allow.txt      a policy with the allowed functions, see funcs.ParsePolicy (optional)
+//	    expected.txt   the expected output
+//	    error.txt      the expected error, used instead of expected.txt
+//
+// The rendered output must match expected.txt exactly. If error.txt is present the template must fail
+// and the error message must contain the contents of error.txt, e.g. the message of a CustomError or
+// "function os.Getenv is not allowed".
+//
+// Running the tests with the environment variable XTEMPLATETEST_UPDATE=1 rewrites expected.txt (or error.txt)
+// with the actual result. The package does not register flags, but if the test binary defines a boolean
+// -update flag (the usual golden file idiom) running the tests with -update does the same.
+package xtemplatetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+)
+
+// File names used in a test case directory.
+const (
+	TemplateFile = "template.tmpl"
+	DataFile     = "data.json"
+	AllowFile    = "allow.txt"
+	ExpectedFile = "expected.txt"
+	ErrorFile    = "error.txt"
+)
+
+// UpdateEnv is the environment variable that enables rewriting the expected outputs.
+const UpdateEnv = "XTEMPLATETEST_UPDATE"
+
+// updateGolden reports whether the expected outputs should be rewritten, see UpdateEnv.
+func updateGolden() bool {
+	if v, ok := os.LookupEnv(UpdateEnv); ok {
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if getter, ok := f.Value.(flag.Getter); ok {
+			b, _ := getter.Get().(bool)
+			return b
+		}
+	}
+	return false
+}
+
+// Run executes every test case in dir as a sub test of t.
+//
+// allowedFunctions are used for test cases without an allow.txt file. Options (e.g. xtemplate.WithDeterministic)
+// in allowedFunctions apply to all test cases.
+func Run(t *testing.T, dir string, allowedFunctions ...xtemplate.AllowedFunctions) {
+	t.Helper()
+
+	var cases []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == TemplateFile {
+			cases = append(cases, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk %s: %v", dir, err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("no test cases found in %s", dir)
+	}
+
+	for _, c := range cases {
+		name, err := filepath.Rel(dir, c)
+		if err != nil {
+			name = c
+		}
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			runCase(t, c, allowedFunctions)
+		})
+	}
+}
+
+func runCase(t *testing.T, dir string, allowedFunctions []xtemplate.AllowedFunctions) {
+	t.Helper()
+
+	text, err := os.ReadFile(filepath.Join(dir, TemplateFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := readData(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowed, err := readAllowed(dir, allowedFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, execErr := render(string(text), data, allowed)
+
+	expectedFile := filepath.Join(dir, ExpectedFile)
+	errorFile := filepath.Join(dir, ErrorFile)
+
+	if updateGolden() {
+		writeGolden(t, expectedFile, errorFile, got, execErr)
+		return
+	}
+
+	wantErr, err := os.ReadFile(errorFile)
+	switch {
+	case err == nil:
+		want := strings.TrimSpace(string(wantErr))
+		if execErr == nil {
+			t.Fatalf("expected error containing %q, got output %q", want, got)
+		}
+		if !strings.Contains(execErr.Error(), want) {
+			t.Fatalf("expected error containing %q, got %q", want, execErr.Error())
+		}
+		return
+	case !errors.Is(err, fs.ErrNotExist):
+		t.Fatal(err)
+	}
+
+	if execErr != nil {
+		t.Fatalf("unexpected error: %v", execErr)
+	}
+	want, err := os.ReadFile(expectedFile)
+	if err != nil {
+		t.Fatalf("%v (run with %s=1 to create it)", err, UpdateEnv)
+	}
+	if got != string(want) {
+		t.Errorf("output mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func writeGolden(t *testing.T, expectedFile, errorFile, got string, execErr error) {
+	t.Helper()
+
+	if execErr != nil {
+		if err := os.WriteFile(errorFile, []byte(errorMessage(execErr)+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(expectedFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			t.Fatal(err)
+		}
+		return
+	}
+	if err := os.WriteFile(expectedFile, []byte(got), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(errorFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+}
+
+// errorMessage returns the most specific message of err.
+func errorMessage(err error) string {
+	var customErr xtemplate.CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Message
+	}
+	var notAllowedErr *xtemplate.FuncNotAllowedError
+	if errors.As(err, &notAllowedErr) {
+		return notAllowedErr.Error()
+	}
+	var nondeterministicErr *xtemplate.NondeterministicError
+	if errors.As(err, &nondeterministicErr) {
+		return nondeterministicErr.Error()
+	}
+	return err.Error()
+}
+
+func readData(dir string) (any, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, DataFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(buf, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", DataFile, err)
+	}
+	return data, nil
+}
+
+func readAllowed(
+	dir string,
+	allowedFunctions []xtemplate.AllowedFunctions,
+) ([]xtemplate.AllowedFunctions, error) {
+	f, err := os.Open(filepath.Join(dir, AllowFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return allowedFunctions, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	policy, err := funcs.ParsePolicy(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", AllowFile, err)
+	}
+	allowed := []xtemplate.AllowedFunctions{policy}
+	for _, a := range allowedFunctions {
+		if o, ok := a.(xtemplate.Option); ok {
+			allowed = append(allowed, o)
+		}
+	}
+	return allowed, nil
+}
+
+func render(text string, data any, allowed []xtemplate.AllowedFunctions) (string, error) {
+	tmpl := template.New(TemplateFile)
+	tmpl = tmpl.Funcs(xtemplate.FuncMap(tmpl, allowed...))
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	err = xtemplate.Execute(tmpl, &buf, data)
+	return buf.String(), err
+}
@@ -0,0 +1,51 @@
+package xtemplatetest_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Eun/xtemplate"
+	"github.com/Eun/xtemplate/funcs"
+	"github.com/Eun/xtemplate/xtemplatetest"
+)
+
+// update is the usual golden file flag of a test package, xtemplatetest must not define a flag with the same name.
+//
+//nolint:gochecknoglobals, unused // defined to detect a redefinition
+var update = flag.Bool("update", false, "rewrite the expected outputs")
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	xtemplatetest.Run(t, "testdata",
+		funcs.Safe,
+		xtemplate.WithOverride(funcs.OSHostname, func() (string, error) {
+			return "example.com", nil
+		}),
+	)
+}
+
+func TestRun_Update(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "greeting"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	err := os.WriteFile(filepath.Join(dir, "greeting", xtemplatetest.TemplateFile),
+		[]byte(`Hello {{ strings.ToUpper "world" }}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(xtemplatetest.UpdateEnv, "1")
+	xtemplatetest.Run(t, dir, funcs.Safe)
+
+	got, err := os.ReadFile(filepath.Join(dir, "greeting", xtemplatetest.ExpectedFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hello WORLD"; string(got) != want {
+		t.Errorf("%s = %q, want %q", xtemplatetest.ExpectedFile, got, want)
+	}
+}
@@ -0,0 +1,1308 @@
+package xtemplate
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file implements the subset of YAML 1.2 used by the yaml namespace: block and flow collections,
+// plain, quoted and block scalars, anchors, aliases and merge keys. Scalars are resolved with the core schema.
+
+// SyntaxError is returned when a document cannot be parsed.
+type SyntaxError struct {
+	Format string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: line %d, column %d: %s", e.Format, e.Line, e.Column, e.Msg)
+}
+
+// maxYAMLDepth limits the nesting of collections.
+const maxYAMLDepth = 1000
+
+// maxYAMLAliasNodes limits the number of nodes aliases expand to, it stops "billion laughs" documents
+// that expand a few bytes of nested aliases to gigabytes.
+const maxYAMLAliasNodes = 100_000
+
+type yamlDocument struct {
+	text string
+	line int
+}
+
+// splitYAML splits a stream into its documents.
+func splitYAML(src string) []yamlDocument {
+	var docs []yamlDocument
+	var cur strings.Builder
+	start := 1
+	explicit := false
+	flush := func() {
+		if explicit || yamlHasContent(cur.String()) {
+			docs = append(docs, yamlDocument{text: cur.String(), line: start})
+		}
+		cur.Reset()
+	}
+	for i, line := range strings.SplitAfter(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSuffix(line, "\n")
+		switch {
+		case isYAMLMarker(trimmed, "---"):
+			flush()
+			explicit = true
+			start = i + 2 //nolint:mnd // lines are 1-based and the document starts on the next line
+			if rest := strings.TrimLeft(trimmed[3:], " \t"); rest != "" && rest[0] != '#' {
+				cur.WriteString(rest + "\n")
+				start = i + 1
+			}
+		case isYAMLMarker(trimmed, "..."):
+			flush()
+			explicit = false
+			start = i + 2 //nolint:mnd // see above
+		default:
+			if !explicit && !yamlHasContent(cur.String()) && strings.HasPrefix(line, "%") {
+				// directives belong to the next document
+				start = i + 2 //nolint:mnd // see above
+				continue
+			}
+			cur.WriteString(line)
+		}
+	}
+	flush()
+	return docs
+}
+
+func isYAMLMarker(line, marker string) bool {
+	return strings.HasPrefix(line, marker) && (len(line) == 3 || line[3] == ' ' || line[3] == '\t')
+}
+
+// yamlHasContent reports whether s contains anything besides whitespace and comments.
+func yamlHasContent(s string) bool {
+	for line := range strings.SplitSeq(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && line[0] != '#' {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalYAML parses a stream that contains at most one document.
+func unmarshalYAML(src string) (any, error) {
+	docs := splitYAML(src)
+	switch len(docs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return parseYAMLDocument(docs[0])
+	default:
+		return nil, &SyntaxError{
+			Format: "yaml",
+			Line:   docs[1].line,
+			Column: 1,
+			Msg:    "expected a single document, use yaml.Split for multi-document streams",
+		}
+	}
+}
+
+func parseYAMLDocument(doc yamlDocument) (any, error) {
+	p := &yamlParser{
+		src:       doc.text,
+		pos:       0,
+		line:      doc.line,
+		lineStart: 0,
+		depth:     0,
+		anchors:   make(map[string]any),
+		sizes:     make(map[string]int),
+		counts:    make(map[yamlNodeID]int),
+		expanded:  0,
+	}
+	p.skipBlank()
+	if p.eof() {
+		return nil, nil
+	}
+	v, err := p.parseNode(-1, false, false)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if !p.eof() {
+		return nil, p.errorf("unexpected content")
+	}
+	return v, nil
+}
+
+type yamlParser struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+	depth     int
+	anchors   map[string]any
+	sizes     map[string]int     // number of nodes of each anchored value, aliases expanded
+	counts    map[yamlNodeID]int // memoized node counts of collections
+	expanded  int                // number of nodes aliases expanded to so far
+}
+
+// yamlNodeID identifies a collection, aliases share collections with their anchors.
+type yamlNodeID struct {
+	ptr uintptr
+	len int
+}
+
+type yamlParserState struct {
+	pos       int
+	line      int
+	lineStart int
+}
+
+func (p *yamlParser) save() yamlParserState {
+	return yamlParserState{pos: p.pos, line: p.line, lineStart: p.lineStart}
+}
+
+func (p *yamlParser) restore(s yamlParserState) {
+	p.pos, p.line, p.lineStart = s.pos, s.line, s.lineStart
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	return &SyntaxError{Format: "yaml", Line: p.line, Column: p.col() + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *yamlParser) col() int {
+	return p.pos - p.lineStart
+}
+
+func (p *yamlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *yamlParser) peek() byte {
+	return p.peekAt(0)
+}
+
+func (p *yamlParser) peekAt(n int) byte {
+	if p.pos+n >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+n]
+}
+
+func (p *yamlParser) advance() {
+	if p.src[p.pos] == '\n' {
+		p.line++
+		p.lineStart = p.pos + 1
+	}
+	p.pos++
+}
+
+func isYAMLBlank(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == 0
+}
+
+func isYAMLFlowIndicator(c byte) bool {
+	return c == ',' || c == '[' || c == ']' || c == '{' || c == '}'
+}
+
+// skipSpaces skips spaces and tabs on the current line.
+func (p *yamlParser) skipSpaces() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.advance()
+	}
+}
+
+// skipComment skips a comment until the end of the line.
+func (p *yamlParser) skipComment() {
+	if p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.advance()
+		}
+	}
+}
+
+// skipBlank skips whitespace, comments and line breaks.
+func (p *yamlParser) skipBlank() {
+	for {
+		p.skipSpaces()
+		p.skipComment()
+		if p.peek() != '\n' {
+			return
+		}
+		p.advance()
+	}
+}
+
+// atLineEnd reports whether only whitespace and a comment follow on the current line.
+func (p *yamlParser) atLineEnd() bool {
+	s := p.save()
+	defer p.restore(s)
+	p.skipSpaces()
+	return p.eof() || p.peek() == '\n' || (p.peek() == '#' && (p.pos == p.lineStart || isYAMLBlank(p.src[p.pos-1])))
+}
+
+func (p *yamlParser) atDocumentMarker() bool {
+	if p.col() != 0 {
+		return false
+	}
+	rest := p.src[p.pos:]
+	return (strings.HasPrefix(rest, "---") || strings.HasPrefix(rest, "...")) && isYAMLBlank(p.peekAt(3))
+}
+
+func (p *yamlParser) atSequenceEntry() bool {
+	return p.peek() == '-' && isYAMLBlank(p.peekAt(1))
+}
+
+// nodeFollows reports whether a node of the collection with the given indentation starts at the current position.
+// Mapping values may be sequences on the same indentation as their key.
+func (p *yamlParser) nodeFollows(parentIndent int, mapValue bool) bool {
+	if p.eof() || p.atDocumentMarker() {
+		return false
+	}
+	if p.col() > parentIndent {
+		return true
+	}
+	return mapValue && p.col() == parentIndent && p.atSequenceEntry()
+}
+
+// parseNode parses the node at the current position. parentIndent is the indentation of the enclosing collection,
+// inline reports whether the node starts on the line of its mapping key where no new collection can start.
+func (p *yamlParser) parseNode(parentIndent int, inline, mapValue bool) (any, error) {
+	if !inline {
+		p.skipBlank()
+		if !p.nodeFollows(parentIndent, mapValue) {
+			return nil, nil
+		}
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxYAMLDepth {
+		return nil, p.errorf("exceeded max depth of %d", maxYAMLDepth)
+	}
+
+	anchor, tag, err := p.parseProperties()
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if (anchor != "" || tag != "") && p.atLineEnd() {
+		v, err = p.parseNode(parentIndent, false, mapValue)
+	} else {
+		v, err = p.parseContent(parentIndent, inline, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if anchor != "" {
+		p.setAnchor(anchor, v)
+	}
+	return v, nil
+}
+
+// parseProperties parses the anchor and the tag of a node.
+func (p *yamlParser) parseProperties() (anchor, tag string, err error) {
+	for range 2 {
+		switch p.peek() {
+		case '&':
+			if anchor != "" {
+				return "", "", p.errorf("multiple anchors")
+			}
+			p.advance()
+			anchor = p.readName()
+			if anchor == "" {
+				return "", "", p.errorf("missing anchor name")
+			}
+		case '!':
+			if tag != "" {
+				return "", "", p.errorf("multiple tags")
+			}
+			tag = p.readName()
+		default:
+			return anchor, tag, nil
+		}
+		p.skipSpaces()
+	}
+	return anchor, tag, nil
+}
+
+func (p *yamlParser) readName() string {
+	start := p.pos
+	for !isYAMLBlank(p.peek()) && !isYAMLFlowIndicator(p.peek()) {
+		p.advance()
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *yamlParser) setAnchor(name string, v any) {
+	p.anchors[name] = v
+	p.sizes[name] = p.nodeCount(v)
+}
+
+// nodeCount returns the number of nodes of v with aliases expanded.
+func (p *yamlParser) nodeCount(v any) int {
+	var id yamlNodeID
+	switch x := v.(type) {
+	case map[any]any:
+		id = yamlNodeID{ptr: reflect.ValueOf(x).Pointer(), len: len(x)}
+	case []any:
+		id = yamlNodeID{ptr: reflect.ValueOf(x).Pointer(), len: len(x)}
+	default:
+		return 1
+	}
+	if n, ok := p.counts[id]; ok {
+		return n
+	}
+	n := 1
+	switch x := v.(type) {
+	case map[any]any:
+		for _, e := range x {
+			n += p.nodeCount(e)
+		}
+	case []any:
+		for _, e := range x {
+			n += p.nodeCount(e)
+		}
+	}
+	p.counts[id] = n
+	return n
+}
+
+func (p *yamlParser) parseAlias() (any, error) {
+	p.advance()
+	name := p.readName()
+	v, ok := p.anchors[name]
+	if !ok {
+		return nil, p.errorf("unknown anchor %q", name)
+	}
+	p.expanded += p.sizes[name]
+	if p.expanded > maxYAMLAliasNodes {
+		return nil, p.errorf("aliases expand to more than %d nodes", maxYAMLAliasNodes)
+	}
+	return v, nil
+}
+
+func (p *yamlParser) parseContent(parentIndent int, inline bool, tag string) (any, error) {
+	indent := p.col()
+	switch c := p.peek(); {
+	case p.atSequenceEntry():
+		if inline {
+			return nil, p.errorf("sequence entries are not allowed here")
+		}
+		return p.parseBlockSequence(indent)
+	case c == '?' && isYAMLBlank(p.peekAt(1)):
+		return nil, p.errorf("complex mapping keys are not supported")
+	case c == '|' || c == '>':
+		return p.parseBlockScalar(parentIndent)
+	case c == '*':
+		v, err := p.parseAlias()
+		if err != nil {
+			return nil, err
+		}
+		return v, p.expectLineEnd()
+	case c == '[' || c == '{':
+		v, err := p.parseFlowNode()
+		if err != nil {
+			return nil, err
+		}
+		return v, p.expectLineEnd()
+	}
+
+	line, quoted, err := p.parseScalarLine()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.peek() == ':' && isYAMLBlank(p.peekAt(1)) {
+		if inline {
+			return nil, p.errorf("mapping values are not allowed here")
+		}
+		return p.parseBlockMapping(indent, line)
+	}
+	if quoted {
+		return line, p.expectLineEnd()
+	}
+	line, err = p.plainContinuation(line, parentIndent)
+	if err != nil {
+		return nil, err
+	}
+	return resolveYAMLScalar(line, tag), nil
+}
+
+func (p *yamlParser) expectLineEnd() error {
+	if !p.atLineEnd() {
+		p.skipSpaces()
+		return p.errorf("unexpected content")
+	}
+	return nil
+}
+
+// parseScalarLine parses a quoted scalar or the part of a plain scalar on the current line.
+func (p *yamlParser) parseScalarLine() (s string, quoted bool, err error) {
+	switch p.peek() {
+	case '"':
+		s, err = p.parseDoubleQuoted()
+		return s, true, err
+	case '\'':
+		s, err = p.parseSingleQuoted()
+		return s, true, err
+	}
+	start := p.pos
+	end := p.pos
+	for !p.eof() && p.peek() != '\n' {
+		c := p.peek()
+		if c == ':' && isYAMLBlank(p.peekAt(1)) {
+			break
+		}
+		if c == '#' && p.pos > start && (p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+		p.advance()
+		if c != ' ' && c != '\t' {
+			end = p.pos
+		}
+	}
+	return p.src[start:end], false, nil
+}
+
+// plainContinuation appends the continuation lines of a multi-line plain scalar.
+func (p *yamlParser) plainContinuation(s string, parentIndent int) (string, error) {
+	for {
+		state := p.save()
+		p.skipSpaces()
+		if p.peek() != '\n' {
+			p.restore(state)
+			return s, nil
+		}
+		breaks := 0
+		for p.peek() == '\n' {
+			p.advance()
+			breaks++
+			p.skipSpaces()
+		}
+		if p.eof() || p.col() <= parentIndent || p.atDocumentMarker() || p.peek() == '#' {
+			p.restore(state)
+			return s, nil
+		}
+		line, _, _ := p.parseScalarLine()
+		if p.peek() == ':' && isYAMLBlank(p.peekAt(1)) {
+			return "", p.errorf("mapping values are not allowed here")
+		}
+		if breaks == 1 {
+			s += " " + line
+		} else {
+			s += strings.Repeat("\n", breaks-1) + line
+		}
+	}
+}
+
+func (p *yamlParser) parseBlockSequence(indent int) ([]any, error) {
+	out := make([]any, 0)
+	for {
+		p.advance() // '-'
+		v, err := p.parseNode(indent, false, false)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		p.skipBlank()
+		if p.eof() || p.atDocumentMarker() || p.col() < indent {
+			return out, nil
+		}
+		if p.col() > indent {
+			return nil, p.errorf("bad indentation of a sequence entry")
+		}
+		if !p.atSequenceEntry() {
+			// a mapping key on the indentation of a sequence that is the value of a mapping
+			return out, nil
+		}
+	}
+}
+
+func (p *yamlParser) parseBlockMapping(indent int, firstKey string) (map[any]any, error) {
+	m := make(map[any]any)
+	var merges []any
+	key := firstKey
+	for {
+		if _, ok := m[key]; ok {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		p.advance() // ':'
+		p.skipSpaces()
+		v, err := p.parseNode(indent, !p.atLineEnd(), true)
+		if err != nil {
+			return nil, err
+		}
+		if key == "<<" {
+			merges = append(merges, v)
+		} else {
+			m[key] = v
+		}
+
+		p.skipBlank()
+		if p.eof() || p.atDocumentMarker() || p.col() < indent {
+			break
+		}
+		if p.col() > indent {
+			return nil, p.errorf("bad indentation of a mapping entry")
+		}
+		if key, err = p.parseMappingKey(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.merge(m, merges); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (p *yamlParser) parseMappingKey() (string, error) {
+	if p.atSequenceEntry() {
+		return "", p.errorf("expected a mapping key, got a sequence entry")
+	}
+	if p.peek() == '?' && isYAMLBlank(p.peekAt(1)) {
+		return "", p.errorf("complex mapping keys are not supported")
+	}
+	if _, _, err := p.parseProperties(); err != nil {
+		return "", err
+	}
+	key, _, err := p.parseScalarLine()
+	if err != nil {
+		return "", err
+	}
+	p.skipSpaces()
+	if p.peek() != ':' || !isYAMLBlank(p.peekAt(1)) {
+		return "", p.errorf("expected ':' after mapping key %q", key)
+	}
+	return key, nil
+}
+
+// merge applies the merge keys (<<) to m, keys that are already present are not overwritten.
+func (p *yamlParser) merge(m map[any]any, merges []any) error {
+	for _, v := range merges {
+		sources, ok := v.([]any)
+		if !ok {
+			sources = []any{v}
+		}
+		for _, src := range sources {
+			sm, ok := src.(map[any]any)
+			if !ok {
+				return p.errorf("merge key value must be a mapping or a sequence of mappings")
+			}
+			for k, v := range sm {
+				if _, exists := m[k]; !exists {
+					m[k] = v
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar.
+//
+//nolint:gocognit, cyclop, funlen // cannot be simplified
+func (p *yamlParser) parseBlockScalar(parentIndent int) (string, error) {
+	folded := p.peek() == '>'
+	p.advance()
+	var chomp byte
+	explicit := 0
+	for range 2 {
+		switch c := p.peek(); {
+		case c == '+' || c == '-':
+			chomp = c
+			p.advance()
+		case c >= '1' && c <= '9':
+			explicit = int(c - '0')
+			p.advance()
+		}
+	}
+	if !p.atLineEnd() {
+		return "", p.errorf("invalid block scalar header")
+	}
+	p.skipSpaces()
+	p.skipComment()
+	if !p.eof() {
+		p.advance()
+	}
+
+	contentIndent := -1
+	if explicit > 0 {
+		contentIndent = max(parentIndent, 0) + explicit
+	}
+	var lines []string
+	for !p.eof() {
+		n := 0
+		for p.peekAt(n) == ' ' {
+			n++
+		}
+		end := strings.IndexByte(p.src[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.src) - p.pos
+		}
+		text := p.src[p.pos : p.pos+end]
+		if strings.TrimSpace(text) == "" {
+			if contentIndent >= 0 && n > contentIndent {
+				lines = append(lines, text[contentIndent:])
+			} else {
+				lines = append(lines, "")
+			}
+		} else {
+			if contentIndent < 0 {
+				if n <= parentIndent {
+					break
+				}
+				contentIndent = n
+			}
+			if n < contentIndent || (contentIndent == 0 && p.atDocumentMarker()) {
+				break
+			}
+			lines = append(lines, text[contentIndent:])
+		}
+		for range end {
+			p.advance()
+		}
+		if !p.eof() {
+			p.advance()
+		}
+	}
+
+	trailing := 0
+	for trailing < len(lines) && lines[len(lines)-1-trailing] == "" {
+		trailing++
+	}
+	content := lines[:len(lines)-trailing]
+	var b strings.Builder
+	if folded {
+		empty := 0
+		prevMore := false
+		for i, line := range content {
+			if line == "" {
+				empty++
+				continue
+			}
+			more := line[0] == ' ' || line[0] == '\t'
+			switch {
+			case i == 0 || b.Len() == 0:
+				b.WriteString(strings.Repeat("\n", empty))
+			case empty == 0 && !more && !prevMore:
+				b.WriteByte(' ')
+			case !more && !prevMore:
+				b.WriteString(strings.Repeat("\n", empty))
+			default:
+				b.WriteString(strings.Repeat("\n", empty+1))
+			}
+			b.WriteString(line)
+			empty = 0
+			prevMore = more
+		}
+	} else {
+		b.WriteString(strings.Join(content, "\n"))
+	}
+
+	switch {
+	case len(content) == 0 && chomp != '+':
+		return "", nil
+	case chomp == '-':
+		return b.String(), nil
+	case chomp == '+':
+		if len(content) == 0 {
+			return strings.Repeat("\n", trailing), nil
+		}
+		return b.String() + "\n" + strings.Repeat("\n", trailing), nil
+	default:
+		return b.String() + "\n", nil
+	}
+}
+
+// foldQuotedLineBreak folds the line breaks at the current position of a quoted scalar.
+func (p *yamlParser) foldQuotedLineBreak(b *strings.Builder) {
+	s := strings.TrimRight(b.String(), " \t")
+	b.Reset()
+	b.WriteString(s)
+	breaks := 0
+	for p.peek() == '\n' {
+		p.advance()
+		breaks++
+		p.skipSpaces()
+	}
+	if breaks == 1 {
+		b.WriteByte(' ')
+	} else {
+		b.WriteString(strings.Repeat("\n", breaks-1))
+	}
+}
+
+func (p *yamlParser) parseSingleQuoted() (string, error) {
+	p.advance()
+	var b strings.Builder
+	for {
+		switch {
+		case p.eof():
+			return "", p.errorf("unterminated string")
+		case p.peek() == '\'' && p.peekAt(1) == '\'':
+			b.WriteByte('\'')
+			p.advance()
+			p.advance()
+		case p.peek() == '\'':
+			p.advance()
+			return b.String(), nil
+		case p.peek() == '\n':
+			p.foldQuotedLineBreak(&b)
+		default:
+			b.WriteByte(p.peek())
+			p.advance()
+		}
+	}
+}
+
+//nolint:gochecknoglobals // lookup table
+var yamlEscapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v", 'f': "\f", 'r': "\r",
+	'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\", 'N': "\u0085", '_': " ", 'L': " ",
+	'P': " ",
+}
+
+// yamlUnicodeEscapes maps the escapes of code points to the number of hex digits that follow.
+//
+//nolint:gochecknoglobals // lookup table
+var yamlUnicodeEscapes = map[byte]int{'x': 2, 'u': 4, 'U': 8}
+
+//nolint:cyclop // cannot be simplified
+func (p *yamlParser) parseDoubleQuoted() (string, error) {
+	p.advance()
+	var b strings.Builder
+	for {
+		switch {
+		case p.eof():
+			return "", p.errorf("unterminated string")
+		case p.peek() == '"':
+			p.advance()
+			return b.String(), nil
+		case p.peek() == '\n':
+			p.foldQuotedLineBreak(&b)
+		case p.peek() == '\\':
+			p.advance()
+			c := p.peek()
+			if c == '\n' {
+				// escaped line break, the string continues on the next line without a space
+				p.advance()
+				p.skipSpaces()
+				continue
+			}
+			if s, ok := yamlEscapes[c]; ok {
+				b.WriteString(s)
+				p.advance()
+				continue
+			}
+			size := yamlUnicodeEscapes[c]
+			if size == 0 || p.pos+1+size > len(p.src) {
+				return "", p.errorf("invalid escape sequence")
+			}
+			r, err := strconv.ParseUint(p.src[p.pos+1:p.pos+1+size], 16, 32)
+			if err != nil {
+				return "", p.errorf("invalid escape sequence")
+			}
+			b.WriteRune(rune(r))
+			for range size + 1 {
+				p.advance()
+			}
+		default:
+			b.WriteByte(p.peek())
+			p.advance()
+		}
+	}
+}
+
+// parseFlowNode parses a node inside a flow collection, or a flow collection.
+//
+//nolint:cyclop // cannot be simplified
+func (p *yamlParser) parseFlowNode() (any, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxYAMLDepth {
+		return nil, p.errorf("exceeded max depth of %d", maxYAMLDepth)
+	}
+	p.skipBlank()
+	anchor, tag, err := p.parseProperties()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	var v any
+	switch p.peek() {
+	case '[':
+		v, err = p.parseFlowSequence()
+	case '{':
+		v, err = p.parseFlowMapping()
+	case '*':
+		v, err = p.parseAlias()
+	case '"':
+		v, err = p.parseDoubleQuoted()
+	case '\'':
+		v, err = p.parseSingleQuoted()
+	case ']', '}', ',':
+		v = nil
+	default:
+		v = resolveYAMLScalar(p.parseFlowPlain(), tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if anchor != "" {
+		p.setAnchor(anchor, v)
+	}
+	return v, nil
+}
+
+func (p *yamlParser) parseFlowPlain() string {
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		if isYAMLFlowIndicator(c) || (c == ':' && (isYAMLBlank(p.peekAt(1)) || isYAMLFlowIndicator(p.peekAt(1)))) {
+			break
+		}
+		if c == '#' && p.pos > 0 && isYAMLBlank(p.src[p.pos-1]) {
+			break
+		}
+		if c == '\n' {
+			p.foldQuotedLineBreak(&b)
+			continue
+		}
+		b.WriteByte(c)
+		p.advance()
+	}
+	return strings.TrimRight(b.String(), " \t")
+}
+
+func (p *yamlParser) parseFlowSequence() ([]any, error) {
+	p.advance() // '['
+	out := make([]any, 0)
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("unterminated flow sequence")
+		}
+		if p.peek() == ']' {
+			p.advance()
+			return out, nil
+		}
+		v, err := p.parseFlowNode()
+		if err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+		if p.peek() == ':' {
+			// single pair mapping
+			p.advance()
+			value, err := p.parseFlowNode()
+			if err != nil {
+				return nil, err
+			}
+			v = map[any]any{yamlKeyString(v): value}
+			p.skipBlank()
+		}
+		out = append(out, v)
+		if err := p.expectFlowSeparator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *yamlParser) parseFlowMapping() (map[any]any, error) {
+	p.advance() // '{'
+	m := make(map[any]any)
+	var merges []any
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("unterminated flow mapping")
+		}
+		if p.peek() == '}' {
+			p.advance()
+			if err := p.merge(m, merges); err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
+		k, err := p.parseFlowNode()
+		if err != nil {
+			return nil, err
+		}
+		key := yamlKeyString(k)
+		if _, ok := m[key]; ok {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		p.skipBlank()
+		var v any
+		if p.peek() == ':' {
+			p.advance()
+			if v, err = p.parseFlowNode(); err != nil {
+				return nil, err
+			}
+			p.skipBlank()
+		}
+		if key == "<<" {
+			merges = append(merges, v)
+		} else {
+			m[key] = v
+		}
+		if err := p.expectFlowSeparator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *yamlParser) expectFlowSeparator(end byte) error {
+	switch p.peek() {
+	case ',':
+		p.advance()
+		return nil
+	case end:
+		return nil
+	case 0:
+		if end == ']' {
+			return p.errorf("unterminated flow sequence")
+		}
+		return p.errorf("unterminated flow mapping")
+	default:
+		return p.errorf("expected ',' or '%c' in flow collection", end)
+	}
+}
+
+func yamlKeyString(v any) string {
+	if v == nil {
+		return "null"
+	}
+	return toString(v)
+}
+
+//nolint:gochecknoglobals // lookup table
+var (
+	yamlIntRegexp   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloatRegexp = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYAMLScalar resolves a plain scalar with the core schema.
+//
+//nolint:cyclop // cannot be simplified
+func resolveYAMLScalar(s, tag string) any {
+	if tag == "!!str" || tag == "!" {
+		return s
+	}
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+	switch {
+	case yamlIntRegexp.MatchString(s):
+		if i, err := strconv.ParseInt(s, 10, 0); err == nil {
+			return int(i)
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case strings.HasPrefix(s, "0x"), strings.HasPrefix(s, "0o"):
+		if i, err := strconv.ParseInt(s, 0, 0); err == nil {
+			return int(i)
+		}
+	case yamlFloatRegexp.MatchString(s):
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return s
+}
+
+type yamlEncoder struct {
+	b strings.Builder
+}
+
+type yamlContext int
+
+const (
+	yamlTop yamlContext = iota
+	yamlAfterKey
+	yamlAfterDash
+)
+
+// marshalYAML encodes v as a block style document.
+func marshalYAML(v any) (string, error) {
+	var e yamlEncoder
+	if err := e.node(v, 0, yamlTop); err != nil {
+		return "", err
+	}
+	return e.b.String(), nil
+}
+
+// normalizeValue converts values that are not maps, slices or scalars using their JSON representation.
+func normalizeValue(v any) (reflect.Value, error) {
+	switch v.(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+	default:
+		rv := reflect.ValueOf(v)
+		for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return reflect.Value{}, nil
+			}
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return rv, nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return reflect.Value{}, err
+	}
+	if out == nil {
+		return reflect.Value{}, nil
+	}
+	return reflect.ValueOf(out), nil
+}
+
+// node writes v. indent is the indentation of nested lines, ctx tells what was written before on the current line.
+//
+//nolint:cyclop // cannot be simplified
+func (e *yamlEncoder) node(v any, indent int, ctx yamlContext) error {
+	rv, err := normalizeValue(v)
+	if err != nil {
+		return err
+	}
+	switch {
+	case rv.Kind() == reflect.Map && rv.Len() > 0:
+		keys := make([]any, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.Interface())
+		}
+		sortAny(keys)
+		if ctx == yamlAfterKey {
+			e.b.WriteByte('\n')
+		}
+		for i, k := range keys {
+			switch {
+			case i == 0 && ctx == yamlAfterDash:
+				e.b.WriteByte(' ')
+			default:
+				e.b.WriteString(strings.Repeat(" ", indent))
+			}
+			e.b.WriteString(formatYAMLKey(k))
+			e.b.WriteByte(':')
+			if err := e.node(rv.MapIndex(reflect.ValueOf(k)).Interface(), indent+2, yamlAfterKey); err != nil {
+				return err
+			}
+		}
+		return nil
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() > 0 &&
+		rv.Type().Elem().Kind() != reflect.Uint8:
+		if ctx == yamlAfterKey {
+			e.b.WriteByte('\n')
+		}
+		for i := range rv.Len() {
+			switch {
+			case i == 0 && ctx == yamlAfterDash:
+				e.b.WriteByte(' ')
+			default:
+				e.b.WriteString(strings.Repeat(" ", indent))
+			}
+			e.b.WriteByte('-')
+			if err := e.node(rv.Index(i).Interface(), indent+2, yamlAfterDash); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if ctx != yamlTop {
+		e.b.WriteByte(' ')
+	}
+	if s, ok := yamlString(rv); ok && isYAMLLiteral(s) {
+		if ctx == yamlTop {
+			indent = 2
+		}
+		e.literal(s, indent)
+		return nil
+	}
+	s, err := formatYAMLScalar(rv)
+	if err != nil {
+		return err
+	}
+	e.b.WriteString(s)
+	e.b.WriteByte('\n')
+	return nil
+}
+
+func yamlString(rv reflect.Value) (string, bool) {
+	switch {
+	case rv.Kind() == reflect.String:
+		return rv.String(), true
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8:
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return string(b), true
+	default:
+		return "", false
+	}
+}
+
+// isYAMLLiteral reports whether s is a multi-line string that can be written as a literal block scalar.
+func isYAMLLiteral(s string) bool {
+	if !strings.Contains(strings.TrimRight(s, "\n"), "\n") || s[0] == ' ' || s[0] == '\t' || !utf8.ValidString(s) {
+		return false
+	}
+	for line := range strings.SplitSeq(s, "\n") {
+		if line != "" && strings.TrimSpace(line) == "" {
+			return false
+		}
+		for _, r := range line {
+			if !unicode.IsPrint(r) && r != '\t' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *yamlEncoder) literal(s string, indent int) {
+	body := strings.TrimRight(s, "\n")
+	switch trailing := len(s) - len(body); trailing {
+	case 0:
+		e.b.WriteString("|-\n")
+	case 1:
+		e.b.WriteString("|\n")
+	default:
+		e.b.WriteString("|+\n")
+		body += strings.Repeat("\n", trailing-1)
+	}
+	for line := range strings.SplitSeq(body, "\n") {
+		if line != "" {
+			e.b.WriteString(strings.Repeat(" ", indent))
+			e.b.WriteString(line)
+		}
+		e.b.WriteByte('\n')
+	}
+}
+
+func formatYAMLKey(k any) string {
+	rv, err := normalizeValue(k)
+	if err == nil {
+		if s, err := formatYAMLScalar(rv); err == nil {
+			return s
+		}
+	}
+	return formatYAMLString(fmt.Sprint(k))
+}
+
+//nolint:cyclop // cannot be simplified
+func formatYAMLScalar(rv reflect.Value) (string, error) {
+	if !rv.IsValid() {
+		return "null", nil
+	}
+	if n, ok := rv.Interface().(json.Number); ok {
+		return n.String(), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return formatYAMLFloat(rv.Float(), 32), nil //nolint:mnd // bit size
+	case reflect.Float64:
+		return formatYAMLFloat(rv.Float(), 64), nil //nolint:mnd // bit size
+	case reflect.String:
+		return formatYAMLString(rv.String()), nil
+	case reflect.Map:
+		return "{}", nil
+	case reflect.Slice, reflect.Array:
+		if s, ok := yamlString(rv); ok {
+			return formatYAMLString(s), nil
+		}
+		return "[]", nil
+	default:
+		return "", fmt.Errorf("yaml: unsupported type %s", rv.Type()) //nolint:err113 // allow dynamic error
+	}
+}
+
+func formatYAMLFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsInf(f, 1):
+		return ".inf"
+	case math.IsInf(f, -1):
+		return "-.inf"
+	case math.IsNaN(f):
+		return ".nan"
+	}
+	// same format as encoding/json
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+// formatYAMLString returns s as a plain scalar if possible, otherwise double quoted.
+func formatYAMLString(s string) string {
+	if yamlNeedsQuotes(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// yaml11Bools are booleans in YAML 1.1, they are quoted to stay compatible with YAML 1.1 parsers.
+//
+//nolint:gochecknoglobals // lookup table
+var yaml11Bools = map[string]struct{}{
+	"y": {}, "Y": {}, "yes": {}, "Yes": {}, "YES": {}, "n": {}, "N": {}, "no": {}, "No": {}, "NO": {},
+	"on": {}, "On": {}, "ON": {}, "off": {}, "Off": {}, "OFF": {},
+}
+
+//nolint:cyclop // cannot be simplified
+func yamlNeedsQuotes(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return true
+	}
+	if _, ok := resolveYAMLScalar(s, "").(string); !ok {
+		return true
+	}
+	if _, ok := yaml11Bools[s]; ok {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@` \t") || strings.HasPrefix(s, "...") {
+		return true
+	}
+	if last := s[len(s)-1]; last == ' ' || last == '\t' || last == ':' {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") {
+		return true
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}